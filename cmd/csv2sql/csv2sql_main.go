@@ -17,6 +17,12 @@ import (
 
 	"github.com/go-easygen/go-flags"
 
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	_ "github.com/sijms/go-ora/v2"
+
 	"github.com/suntong/csv2sql"
 )
 
@@ -76,6 +82,29 @@ func DoCsv2sql() error {
 	// }
 	// converter.SkipColumns = map[string]bool{"internal_code": true}
 
+	if Opts.BulkLoad && (Opts.ExecDSN != "" || Opts.Workers > 1 || Opts.SplitRows > 0 || Opts.Stdin || Opts.OutputFile != "" || Opts.DDLFile != "" || Opts.DMLFile != "") {
+		log.Fatalf("Error: --bulk-load is only supported by plain (non-streaming, non-parallel, non-exec, non-split) conversion; drop --stdin/--output/--ddl/--dml/--workers/--exec/--split-rows")
+	}
+
+	if Opts.ExecDSN != "" {
+		if Opts.Workers > 1 {
+			return runExecParallel(converter)
+		}
+		return runExec(converter)
+	}
+
+	if Opts.Workers > 1 {
+		return runParallel(converter)
+	}
+
+	if Opts.SplitRows > 0 {
+		return runSplit(converter)
+	}
+
+	if Opts.Stdin || Opts.OutputFile != "" || Opts.DDLFile != "" || Opts.DMLFile != "" {
+		return runStreaming(converter)
+	}
+
 	createStmt, insertStmts, err := converter.Convert()
 	if err != nil {
 		log.Fatalf("Error converting CSV to SQL: %v", err)
@@ -87,3 +116,171 @@ func DoCsv2sql() error {
 	fmt.Println(insertStmts)
 	return nil
 }
+
+// runStreaming drives csv2sql.Converter.Run, resolving the CSV input
+// (stdin or Opts.InputFile) and the DDL/DML output destinations (Opts.DDLFile
+// / Opts.DMLFile / Opts.OutputFile, falling back to stdout) from Opts.
+func runStreaming(converter *csv2sql.CSVToMySQLConverter) error {
+	in := os.Stdin
+	if !Opts.Stdin {
+		if Opts.InputFile == "" {
+			log.Fatalf("Error: either --input or --stdin must be given")
+		}
+		f, err := os.Open(Opts.InputFile)
+		if err != nil {
+			log.Fatalf("Error opening input file: %v", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	ddlOut, dmlOut, closeOutputs, err := openOutputs()
+	if err != nil {
+		log.Fatalf("Error opening output: %v", err)
+	}
+	defer closeOutputs()
+
+	if err := converter.Run(in, ddlOut, dmlOut); err != nil {
+		log.Fatalf("Error converting CSV to SQL: %v", err)
+	}
+	return nil
+}
+
+// runExec drives csv2sql.Converter.Exec, resolving the CSV input (stdin
+// or Opts.InputFile) the same way runStreaming does.
+func runExec(converter *csv2sql.CSVToMySQLConverter) error {
+	in := os.Stdin
+	if !Opts.Stdin {
+		if Opts.InputFile == "" {
+			log.Fatalf("Error: either --input or --stdin must be given")
+		}
+		f, err := os.Open(Opts.InputFile)
+		if err != nil {
+			log.Fatalf("Error opening input file: %v", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if err := converter.Exec(in); err != nil {
+		log.Fatalf("Error executing CSV load: %v", err)
+	}
+	return nil
+}
+
+// runParallel drives csv2sql.Converter.RunParallel, writing the CREATE
+// TABLE statement to the resolved DDL output and the sharded INSERT
+// statements under Opts.ShardDir.
+func runParallel(converter *csv2sql.CSVToMySQLConverter) error {
+	if Opts.InputFile == "" {
+		log.Fatalf("Error: --workers requires --input (not --stdin)")
+	}
+	f, err := os.Open(Opts.InputFile)
+	if err != nil {
+		log.Fatalf("Error opening input file: %v", err)
+	}
+	defer f.Close()
+
+	ddlOut, _, closeOutputs, err := openOutputs()
+	if err != nil {
+		log.Fatalf("Error opening output: %v", err)
+	}
+	defer closeOutputs()
+
+	if err := converter.RunParallel(f, ddlOut, Opts.ShardDir); err != nil {
+		log.Fatalf("Error converting CSV to SQL: %v", err)
+	}
+	return nil
+}
+
+// runExecParallel drives csv2sql.Converter.ExecParallel.
+func runExecParallel(converter *csv2sql.CSVToMySQLConverter) error {
+	if Opts.InputFile == "" {
+		log.Fatalf("Error: --workers requires --input (not --stdin)")
+	}
+	f, err := os.Open(Opts.InputFile)
+	if err != nil {
+		log.Fatalf("Error opening input file: %v", err)
+	}
+	defer f.Close()
+
+	if err := converter.ExecParallel(f); err != nil {
+		log.Fatalf("Error executing CSV load: %v", err)
+	}
+	return nil
+}
+
+// runSplit drives csv2sql.Converter.WriteSplit, resolving the CSV input
+// the same way runStreaming does and writing the CREATE TABLE statement
+// to the resolved DDL output, with the DML rotated across
+// <table>.NNNN.sql files under Opts.SplitDir.
+func runSplit(converter *csv2sql.CSVToMySQLConverter) error {
+	in := os.Stdin
+	if !Opts.Stdin {
+		if Opts.InputFile == "" {
+			log.Fatalf("Error: either --input or --stdin must be given")
+		}
+		f, err := os.Open(Opts.InputFile)
+		if err != nil {
+			log.Fatalf("Error opening input file: %v", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	ddlOut, _, closeOutputs, err := openOutputs()
+	if err != nil {
+		log.Fatalf("Error opening output: %v", err)
+	}
+	defer closeOutputs()
+
+	if err := converter.WriteSplit(in, ddlOut, Opts.SplitDir, Opts.SplitRows); err != nil {
+		log.Fatalf("Error converting CSV to SQL: %v", err)
+	}
+	return nil
+}
+
+// openOutputs resolves the DDL and DML writers from Opts.OutputFile,
+// Opts.DDLFile and Opts.DMLFile, falling back to stdout. The returned
+// close func must be called once the caller is done writing.
+func openOutputs() (ddlOut, dmlOut *os.File, closeFn func(), err error) {
+	opened := make([]*os.File, 0, 2)
+	closeFn = func() {
+		for _, f := range opened {
+			f.Close()
+		}
+	}
+
+	open := func(path string) (*os.File, error) {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		opened = append(opened, f)
+		return f, nil
+	}
+
+	ddlOut, dmlOut = os.Stdout, os.Stdout
+	if Opts.OutputFile != "" {
+		f, err := open(Opts.OutputFile)
+		if err != nil {
+			return nil, nil, closeFn, err
+		}
+		ddlOut, dmlOut = f, f
+	}
+	if Opts.DDLFile != "" {
+		f, err := open(Opts.DDLFile)
+		if err != nil {
+			return nil, nil, closeFn, err
+		}
+		ddlOut = f
+	}
+	if Opts.DMLFile != "" {
+		f, err := open(Opts.DMLFile)
+		if err != nil {
+			return nil, nil, closeFn, err
+		}
+		dmlOut = f
+	}
+	return ddlOut, dmlOut, closeFn, nil
+}
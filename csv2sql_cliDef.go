@@ -80,19 +80,59 @@ import (
 
 // The OptsT type defines all the configurable options from cli.
 type OptsT struct {
-	InputFile     string   `short:"i" env:"CSV2SQL_INPUTFILE" description:"Input .csv file" required:"true"`
-	TableName     string   `short:"t" env:"CSV2SQL_TABLENAME" description:"Table name to hold csv data" required:"true"`
-	PrimaryKeys   []string `short:"k" env:"CSV2SQL_PRIMARYKEYS" description:"Primary keys of the table"`
-	Delimiter     string   `short:"d" env:"CSV2SQL_DELIMITER" description:"Delimiter char of csv data" default:","`
-	NoHeader      bool     `short:"H" env:"CSV2SQL_NOHEADER" description:"Not having csv header"`
-	NoBatchInsert bool     `short:"B" long:"bi" env:"CSV2SQL_NOBATCHINSERT" description:"No batch insert"`
-	BatchSize     int      `long:"bs" env:"CSV2SQL_BATCHSIZE" description:"BatchSize" default:"100"`
-	VarcharLength int      `long:"vl" env:"CSV2SQL_VARCHARLENGTH" description:"Varchar length" default:"255"`
-	TextThreshold int      `long:"tt" env:"CSV2SQL_TEXTTHRESHOLD" description:"Text length threshold" default:"100"`
-	MaxSampleSize int      `long:"mss" env:"CSV2SQL_MAXSAMPLESIZE" description:"Max sample size to determine column types" default:"1000"`
-	Verbflg       func()   `short:"v" long:"verbose" description:"Verbose mode (Multiple -v options increase the verbosity)"`
-	Verbose       int
-	Version       func() `short:"V" long:"version" description:"Show program version and exit"`
+	InputFile       string   `short:"i" env:"CSV2SQL_INPUTFILE" description:"Input .csv file (omit when --stdin is set)"`
+	TableName       string   `short:"t" env:"CSV2SQL_TABLENAME" description:"Table name to hold csv data" required:"true"`
+	Dialect         string   `long:"dialect" env:"CSV2SQL_DIALECT" description:"Target SQL dialect: mysql, postgres, sqlite, mssql, oracle" default:"mysql"`
+	PrimaryKeys     []string `short:"k" env:"CSV2SQL_PRIMARYKEYS" description:"Primary keys of the table"`
+	Delimiter       string   `short:"d" env:"CSV2SQL_DELIMITER" description:"Delimiter char of csv data" default:","`
+	NoHeader        bool     `short:"H" env:"CSV2SQL_NOHEADER" description:"Not having csv header"`
+	NoBatchInsert   bool     `short:"B" long:"bi" env:"CSV2SQL_NOBATCHINSERT" description:"No batch insert"`
+	BatchSize       int      `long:"bs" env:"CSV2SQL_BATCHSIZE" description:"BatchSize" default:"100"`
+	VarcharLength   int      `long:"vl" env:"CSV2SQL_VARCHARLENGTH" description:"Varchar length" default:"255"`
+	TextThreshold   int      `long:"tt" env:"CSV2SQL_TEXTTHRESHOLD" description:"Text length threshold" default:"100"`
+	MaxSampleSize   int      `long:"mss" env:"CSV2SQL_MAXSAMPLESIZE" description:"Max sample size to determine column types" default:"1000"`
+	BulkLoad        bool     `long:"bulk-load" env:"CSV2SQL_BULKLOAD" description:"Emit a native bulk-load script (LOAD DATA/COPY/.import/BULK INSERT) and CSV sidecar instead of INSERT statements"`
+	CSVSeparator    string   `long:"csv-separator" env:"CSV2SQL_CSVSEPARATOR" description:"Field separator written into the bulk-load CSV sidecar" default:","`
+	CSVDelimiter    string   `long:"csv-delimiter" env:"CSV2SQL_CSVDELIMITER" description:"Field enclosure (quote) char written into the bulk-load CSV sidecar" default:"\""`
+	CSVEscape       string   `long:"csv-escape" env:"CSV2SQL_CSVESCAPE" description:"Escape char written into the bulk-load CSV sidecar" default:"\\"`
+	CSVNull         string   `long:"csv-null" env:"CSV2SQL_CSVNULL" description:"NULL sentinel written into the bulk-load CSV sidecar" default:"\\N"`
+	InQuote         string   `long:"in-quote" env:"CSV2SQL_INQUOTE" description:"Quote char expected in the input CSV; encoding/csv only supports \" so any other value is rejected" default:"\""`
+	InEscape        string   `long:"in-escape" env:"CSV2SQL_INESCAPE" description:"Escape char expected in the input CSV; not supported by encoding/csv, so setting this always errors"`
+	InComment       string   `long:"in-comment" env:"CSV2SQL_INCOMMENT" description:"Lines beginning with this char are ignored as comments when reading the input CSV"`
+	LazyQuotes      bool     `long:"lazy-quotes" env:"CSV2SQL_LAZYQUOTES" description:"Relax quote parsing of the input CSV: allow a bare quote in an unquoted field and a non-doubled quote in a quoted field"`
+	FieldsPerRecord int      `long:"fields-per-record" env:"CSV2SQL_FIELDSPERRECORD" description:"Expected number of fields per input CSV record; 0 (default) infers it from the first record, negative disables the check"`
+	NullStrings     []string `long:"null" env:"CSV2SQL_NULLSTRINGS" description:"Additional NULL sentinel value in the input CSV, compared case-insensitively (repeatable; in addition to the empty string and NULL)"`
+	Stdin           bool     `long:"stdin" env:"CSV2SQL_STDIN" description:"Read CSV from stdin instead of --input, using single-pass streaming"`
+	OutputFile      string   `long:"output" env:"CSV2SQL_OUTPUT" description:"Write both DDL and DML to this file instead of stdout"`
+	DDLFile         string   `long:"ddl" env:"CSV2SQL_DDLFILE" description:"Write the CREATE TABLE statement to this file instead of stdout"`
+	DMLFile         string   `long:"dml" env:"CSV2SQL_DMLFILE" description:"Write the INSERT statements to this file instead of stdout (not allowed with --bulk-load)"`
+	SplitRows       int      `long:"split-rows" env:"CSV2SQL_SPLITROWS" description:"Rotate DML output to a new <table>.NNNN.sql file under --split-dir once the current file holds at least N rows; a batch INSERT is never split across files (requires --split-dir)"`
+	SplitDir        string   `long:"split-dir" env:"CSV2SQL_SPLITDIR" description:"Directory to write <table>.NNNN.sql files into, when --split-rows is set" default:"."`
+	NoTypeSample    bool     `long:"no-type-sample" env:"CSV2SQL_NOTYPESAMPLE" description:"With --split-rows, skip column-type sampling entirely and treat every non-forced, non-skipped column as TEXT, for a true single pass over inputs too large to sample twice; all other output modes ignore it and still sample"`
+	DateFormats     []string `long:"date-format" env:"CSV2SQL_DATEFORMATS" description:"Go time layout tried, in order, to recognize date/datetime columns (repeatable; defaults to RFC3339 and common layouts)"`
+	InputTZ         string   `long:"input-tz" env:"CSV2SQL_INPUTTZ" description:"Time zone (IANA name, e.g. America/New_York) that naive date/datetime values in the input are interpreted in; layouts with their own offset (like RFC3339) ignore this" default:"UTC"`
+	OutputTZ        string   `long:"output-tz" env:"CSV2SQL_OUTPUTTZ" description:"Time zone that normalized DATE/DATETIME values are converted into before being rendered in INSERT statements" default:"UTC"`
+	InferEpoch      bool     `long:"infer-epoch" env:"CSV2SQL_INFEREPOCH" description:"Also recognize 10-digit/13-digit all-numeric values as Unix epoch seconds/milliseconds when inferring DATE/DATETIME columns; off by default since plain numeric IDs (phone numbers, order IDs) match the same shape"`
+	InferBool       bool     `long:"infer-bool" env:"CSV2SQL_INFERBOOL" description:"Infer BOOLEAN for columns whose values are exclusively true/false, t/f, yes/no or 1/0"`
+	InferJSON       bool     `long:"infer-json" env:"CSV2SQL_INFERJSON" description:"Infer JSON for columns whose values are all valid JSON objects/arrays"`
+	InferUUID       bool     `long:"infer-uuid" env:"CSV2SQL_INFERUUID" description:"Infer CHAR(36) for columns whose values are all RFC 4122 UUIDs"`
+	InferEnum       bool     `long:"infer-enum" env:"CSV2SQL_INFERENUM" description:"Infer ENUM(...) for columns whose sampled values have no more than --enum-threshold distinct values"`
+	EnumThreshold   int      `long:"enum-threshold" env:"CSV2SQL_ENUMTHRESHOLD" description:"Max distinct sampled values for a column to still be eligible for ENUM inference" default:"16"`
+	ExecDSN         string   `long:"exec" env:"CSV2SQL_EXEC" description:"Open this database/sql DSN and execute the DDL/DML directly instead of writing SQL text"`
+	TxSize          int      `long:"tx-size" env:"CSV2SQL_TXSIZE" description:"Rows per transaction when --exec is set" default:"500"`
+	IfNotExists     bool     `long:"if-not-exists" env:"CSV2SQL_IFNOTEXISTS" description:"Add IF NOT EXISTS to the CREATE TABLE statement"`
+	Truncate        bool     `long:"truncate" env:"CSV2SQL_TRUNCATE" description:"Truncate the table before loading, when --exec is set"`
+	DropFirst       bool     `long:"drop-first" env:"CSV2SQL_DROPFIRST" description:"Drop the table before creating it, when --exec is set"`
+	DeadlockRetries int      `long:"deadlock-retries" env:"CSV2SQL_DEADLOCKRETRIES" description:"Retries for a transaction that fails with a deadlock/serialization error, when --exec is set" default:"3"`
+	SSLCA           string   `long:"ssl-ca" env:"CSV2SQL_SSLCA" description:"CA certificate file for a TLS connection, when --exec is set"`
+	SSLCert         string   `long:"ssl-cert" env:"CSV2SQL_SSLCERT" description:"Client certificate file for a TLS connection, when --exec is set"`
+	SSLKey          string   `long:"ssl-key" env:"CSV2SQL_SSLKEY" description:"Client private key file for a TLS connection, when --exec is set"`
+	Workers         int      `long:"workers" env:"CSV2SQL_WORKERS" description:"Parallel worker goroutines loading rows (text sharding or --exec); row ordering is not guaranteed unless --workers=1" default:"1"`
+	ShardDir        string   `long:"shard-dir" env:"CSV2SQL_SHARDDIR" description:"Directory to write inserts.NNNN.sql shard files into, when --workers > 1 without --exec" default:"."`
+	AutoIncrement   string   `long:"auto-increment" env:"CSV2SQL_AUTOINCREMENT" description:"Column to render as the dialect's auto-increment primary key (AUTO_INCREMENT/SERIAL/AUTOINCREMENT/IDENTITY/GENERATED AS IDENTITY)"`
+	Verbflg         func()   `short:"v" long:"verbose" description:"Verbose mode (Multiple -v options increase the verbosity)"`
+	Verbose         int
+	Version         func() `short:"V" long:"version" description:"Show program version and exit"`
 }
 
 // Template for type define ends here
@@ -0,0 +1,779 @@
+package csv2sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect abstracts the SQL syntax differences between target database
+// engines so the converter can emit DDL and DML for more than just MySQL.
+// Inferred column types are expressed as canonical tokens (BIGINT,
+// DECIMAL(p,s), VARCHAR(n), TEXT, DATE, DATETIME, ...); MapType translates
+// a canonical token into the dialect's native type name.
+type Dialect interface {
+	// Name is the dialect's short identifier, as accepted by --dialect.
+	Name() string
+	// QuoteIdent quotes a table or column identifier.
+	QuoteIdent(name string) string
+	// MapType translates a canonical inferred type into the dialect's
+	// native type name.
+	MapType(genericType string) string
+	// EscapeString renders value as a complete, dialect-correct SQL
+	// string literal (including the surrounding quotes).
+	EscapeString(value string) string
+	// FormatCreateTable renders a full CREATE TABLE statement. columns
+	// are already-quoted "name type" fragments in declaration order.
+	// When ifNotExists is set, the statement must not fail if table
+	// already exists.
+	FormatCreateTable(table string, columns []string, ifNotExists bool) string
+	// FormatDropTable renders a "DROP TABLE IF EXISTS" statement, used by
+	// --drop-first ahead of the DDL phase in --exec mode.
+	FormatDropTable(table string) string
+	// FormatTruncateTable renders a statement that empties table without
+	// dropping it, used by --truncate in --exec mode.
+	FormatTruncateTable(table string) string
+	// FormatBatchInsert renders a multi-row INSERT statement.
+	FormatBatchInsert(table string, columns []string, rows []string) string
+	// PlaceholderInsert renders a single-row parameterized INSERT
+	// statement, using the dialect's native bind-variable syntax, for use
+	// with a prepared database/sql statement in --exec mode.
+	PlaceholderInsert(table string, columns []string) string
+	// DriverName is the database/sql driver name this dialect expects to
+	// be registered under (by a blank import in the caller) for --exec.
+	DriverName() string
+	// LoadDataStatement renders the dialect's native bulk-load script
+	// (LOAD DATA INFILE, COPY, .import, ...) that loads dataFile, a CSV
+	// written according to format, into table.
+	LoadDataStatement(table string, columns []string, dataFile string, format CSVFormat) string
+	// BooleanLiteral renders a boolean value as the dialect's native
+	// literal, for dialects (like MSSQL) with no true BOOLEAN type.
+	BooleanLiteral(value bool) string
+	// AutoIncrementType renders the column type and trailing clause used
+	// for an --auto-increment column: colType replaces the plain
+	// MapType result (e.g. postgres substitutes SERIAL/BIGSERIAL for the
+	// integer type), clause is appended after it (e.g. AUTO_INCREMENT,
+	// IDENTITY(1,1)), and inlinePrimaryKey reports whether clause already
+	// makes the column a primary key on its own, so the caller must not
+	// also emit a separate PRIMARY KEY (...) constraint naming it.
+	AutoIncrementType(genericType string) (colType, clause string, inlinePrimaryKey bool)
+	// ReservedWords returns the dialect's keyword set (lowercased) that
+	// sanitizeColumnName must not emit unquoted, so sanitizeHeaders knows
+	// when to disambiguate a column name derived from a header like
+	// "order" or "select".
+	ReservedWords() map[string]bool
+	// MaxIdentifierLength is the longest unquoted identifier the dialect
+	// accepts; sanitizeHeaders truncates longer column names rather than
+	// let them silently break DDL.
+	MaxIdentifierLength() int
+}
+
+// newWordSet builds a lowercased keyword lookup set from a list of words,
+// for use as a dialect's ReservedWords().
+func newWordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[strings.ToLower(w)] = true
+	}
+	return set
+}
+
+// CSVFormat describes the on-disk CSV layout a bulk-load script should
+// expect, independent of the CSV dialect used to read the input file.
+type CSVFormat struct {
+	Separator string // field separator, e.g. ","
+	Quote     string // field enclosure, e.g. "\""
+	Escape    string // escape character, e.g. "\\"
+	Null      string // sentinel written for NULL values, e.g. "\\N"
+}
+
+// dialects maps the --dialect flag values to their implementation.
+var dialects = map[string]Dialect{
+	"mysql":    MySQLDialect{},
+	"postgres": PostgresDialect{},
+	"sqlite":   SQLiteDialect{},
+	"mssql":    MSSQLDialect{},
+	"oracle":   OracleDialect{},
+}
+
+// DialectByName looks up a Dialect by its --dialect flag value, defaulting
+// to MySQL when name is empty.
+func DialectByName(name string) (Dialect, error) {
+	if name == "" {
+		name = "mysql"
+	}
+	d, ok := dialects[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect %q", name)
+	}
+	return d, nil
+}
+
+func formatInsertInto(quotedTable, columnList, valuesSQL string) string {
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES\n%s;\n", quotedTable, columnList, valuesSQL)
+}
+
+// splitUnsigned strips the " UNSIGNED" suffix inference appends to
+// integer types, since only MySQL has a native UNSIGNED modifier; the
+// other dialects need to widen instead.
+func splitUnsigned(genericType string) (base string, unsigned bool) {
+	if strings.HasSuffix(genericType, " UNSIGNED") {
+		return strings.TrimSuffix(genericType, " UNSIGNED"), true
+	}
+	return genericType, false
+}
+
+// enumFallback maps a canonical ENUM('a','b',...) token to a VARCHAR sized
+// to fit the longest member, for dialects with no inline enum type syntax.
+func enumFallback(genericType string) string {
+	inner := strings.TrimSuffix(strings.TrimPrefix(genericType, "ENUM("), ")")
+	length := 1
+	for _, member := range strings.Split(inner, ",") {
+		if len(member) > length {
+			length = len(member)
+		}
+	}
+	return fmt.Sprintf("VARCHAR(%d)", length)
+}
+
+////////////////////////////////////////////////////////////////////////////
+// MySQL
+
+// MySQLDialect targets MySQL/MariaDB.
+type MySQLDialect struct{}
+
+func (MySQLDialect) Name() string { return "mysql" }
+
+func (MySQLDialect) QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (MySQLDialect) MapType(genericType string) string {
+	return genericType
+}
+
+func (MySQLDialect) EscapeString(value string) string {
+	escaped := strings.ReplaceAll(value, "\\", "\\\\")
+	escaped = strings.ReplaceAll(escaped, "'", "''")
+	return "'" + escaped + "'"
+}
+
+func (d MySQLDialect) FormatCreateTable(table string, columns []string, ifNotExists bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("-- DROP TABLE %s;\n", table))
+	sb.WriteString("CREATE TABLE ")
+	if ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+	sb.WriteString(fmt.Sprintf("%s (\n", d.QuoteIdent(table)))
+	sb.WriteString(strings.Join(columns, ",\n"))
+	sb.WriteString("\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;")
+	return sb.String()
+}
+
+func (d MySQLDialect) FormatDropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", d.QuoteIdent(table))
+}
+
+func (d MySQLDialect) FormatTruncateTable(table string) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s;", d.QuoteIdent(table))
+}
+
+func (d MySQLDialect) FormatBatchInsert(table string, columns []string, rows []string) string {
+	return formatInsertInto(d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(rows, ",\n"))
+}
+
+func (d MySQLDialect) PlaceholderInsert(table string, columns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(placeholders, ", "))
+}
+
+func (MySQLDialect) DriverName() string { return "mysql" }
+
+func (d MySQLDialect) AutoIncrementType(genericType string) (colType, clause string, inlinePrimaryKey bool) {
+	return d.MapType(genericType), "AUTO_INCREMENT", false
+}
+
+func (d MySQLDialect) LoadDataStatement(table string, columns []string, dataFile string, format CSVFormat) string {
+	return fmt.Sprintf("LOAD DATA LOCAL INFILE '%s'\nINTO TABLE %s\nFIELDS TERMINATED BY '%s'\nOPTIONALLY ENCLOSED BY '%s'\nESCAPED BY '%s'\nLINES TERMINATED BY '\\n'\n(%s);\n",
+		dataFile, d.QuoteIdent(table), format.Separator, format.Quote, format.Escape, quoteIdentList(d, columns))
+}
+
+func (MySQLDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// mysqlReservedWords is a non-exhaustive set of MySQL reserved keywords
+// likely to show up as CSV column headers.
+var mysqlReservedWords = newWordSet(
+	"add", "all", "alter", "and", "as", "asc", "between", "by", "case",
+	"check", "column", "condition", "constraint", "create", "cross",
+	"current_date", "current_time", "current_timestamp", "database",
+	"default", "delete", "desc", "distinct", "drop", "else", "exists",
+	"false", "for", "foreign", "from", "group", "having", "in", "index",
+	"inner", "insert", "int", "into", "is", "join", "key", "left", "like",
+	"limit", "not", "null", "on", "or", "order", "outer", "primary",
+	"references", "right", "select", "set", "table", "then", "to", "true",
+	"union", "unique", "update", "using", "values", "when", "where",
+)
+
+func (MySQLDialect) ReservedWords() map[string]bool { return mysqlReservedWords }
+
+// MySQL identifier limit: https://dev.mysql.com/doc/refman/8.0/en/identifier-length.html
+func (MySQLDialect) MaxIdentifierLength() int { return 64 }
+
+////////////////////////////////////////////////////////////////////////////
+// PostgreSQL
+
+// PostgresDialect targets PostgreSQL.
+type PostgresDialect struct{}
+
+func (PostgresDialect) Name() string { return "postgres" }
+
+func (PostgresDialect) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (PostgresDialect) MapType(genericType string) string {
+	base, unsigned := splitUnsigned(genericType)
+	switch {
+	case base == "TINYINT":
+		return "SMALLINT"
+	case base == "SMALLINT":
+		if unsigned {
+			return "INTEGER"
+		}
+		return "SMALLINT"
+	case base == "INT":
+		if unsigned {
+			return "BIGINT"
+		}
+		return "INTEGER"
+	case base == "BIGINT":
+		if unsigned {
+			return "NUMERIC(20,0)"
+		}
+		return "BIGINT"
+	case strings.HasPrefix(genericType, "DECIMAL"):
+		return "NUMERIC" + strings.TrimPrefix(genericType, "DECIMAL")
+	case genericType == "DATETIME":
+		return "TIMESTAMP"
+	case strings.HasPrefix(genericType, "ENUM("):
+		return enumFallback(genericType)
+	default:
+		return genericType
+	}
+}
+
+func (PostgresDialect) EscapeString(value string) string {
+	escaped := strings.ReplaceAll(value, "'", "''")
+	if strings.Contains(value, "\\") {
+		escaped = strings.ReplaceAll(escaped, "\\", "\\\\")
+		return "E'" + escaped + "'"
+	}
+	return "'" + escaped + "'"
+}
+
+func (d PostgresDialect) FormatCreateTable(table string, columns []string, ifNotExists bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("-- DROP TABLE %s;\n", table))
+	sb.WriteString("CREATE TABLE ")
+	if ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+	sb.WriteString(fmt.Sprintf("%s (\n", d.QuoteIdent(table)))
+	sb.WriteString(strings.Join(columns, ",\n"))
+	sb.WriteString("\n);")
+	return sb.String()
+}
+
+func (d PostgresDialect) FormatDropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", d.QuoteIdent(table))
+}
+
+func (d PostgresDialect) FormatTruncateTable(table string) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s;", d.QuoteIdent(table))
+}
+
+func (d PostgresDialect) FormatBatchInsert(table string, columns []string, rows []string) string {
+	return formatInsertInto(d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(rows, ",\n"))
+}
+
+func (d PostgresDialect) PlaceholderInsert(table string, columns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(placeholders, ", "))
+}
+
+func (PostgresDialect) DriverName() string { return "postgres" }
+
+func (PostgresDialect) AutoIncrementType(genericType string) (colType, clause string, inlinePrimaryKey bool) {
+	base, _ := splitUnsigned(genericType)
+	if base == "BIGINT" {
+		return "BIGSERIAL", "", false
+	}
+	return "SERIAL", "", false
+}
+
+func (d PostgresDialect) LoadDataStatement(table string, columns []string, dataFile string, format CSVFormat) string {
+	return fmt.Sprintf("\\copy %s (%s) FROM '%s' WITH (FORMAT csv, DELIMITER '%s', QUOTE '%s', NULL '%s')\n",
+		d.QuoteIdent(table), quoteIdentList(d, columns), dataFile, format.Separator, format.Quote, format.Null)
+}
+
+func (PostgresDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// postgresReservedWords is a non-exhaustive set of PostgreSQL reserved
+// keywords likely to show up as CSV column headers.
+var postgresReservedWords = newWordSet(
+	"all", "analyse", "analyze", "and", "any", "array", "as", "asc",
+	"between", "case", "cast", "check", "collate", "column", "constraint",
+	"create", "current_date", "current_time", "current_timestamp",
+	"current_user", "default", "deferrable", "desc", "distinct", "do",
+	"else", "end", "except", "exists", "false", "for", "foreign", "from",
+	"grant", "group", "having", "in", "index", "insert", "intersect",
+	"into", "is", "join", "key", "left", "like", "limit", "not", "null",
+	"offset", "on", "or", "order", "outer", "primary", "references",
+	"right", "select", "set", "some", "table", "then", "to", "true",
+	"union", "unique", "update", "using", "values", "when", "where",
+)
+
+func (PostgresDialect) ReservedWords() map[string]bool { return postgresReservedWords }
+
+// PostgreSQL identifier limit: https://www.postgresql.org/docs/current/sql-syntax-lexical.html#SQL-SYNTAX-IDENTIFIERS
+func (PostgresDialect) MaxIdentifierLength() int { return 63 }
+
+////////////////////////////////////////////////////////////////////////////
+// SQLite
+
+// SQLiteDialect targets SQLite.
+type SQLiteDialect struct{}
+
+func (SQLiteDialect) Name() string { return "sqlite" }
+
+func (SQLiteDialect) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (SQLiteDialect) MapType(genericType string) string {
+	base, _ := splitUnsigned(genericType)
+	switch {
+	case base == "TINYINT", base == "SMALLINT", base == "INT", base == "BIGINT":
+		return "INTEGER"
+	case strings.HasPrefix(genericType, "DECIMAL"):
+		return "NUMERIC"
+	case strings.HasPrefix(genericType, "VARCHAR"), genericType == "DATE", genericType == "DATETIME":
+		return "TEXT"
+	case strings.HasPrefix(genericType, "ENUM("):
+		return "TEXT"
+	default:
+		return genericType
+	}
+}
+
+func (SQLiteDialect) EscapeString(value string) string {
+	// SQLite has no backslash-escape syntax; doubling the single quote
+	// is sufficient.
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (d SQLiteDialect) FormatCreateTable(table string, columns []string, ifNotExists bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("-- DROP TABLE %s;\n", table))
+	sb.WriteString("CREATE TABLE ")
+	if ifNotExists {
+		sb.WriteString("IF NOT EXISTS ")
+	}
+	sb.WriteString(fmt.Sprintf("%s (\n", d.QuoteIdent(table)))
+	sb.WriteString(strings.Join(columns, ",\n"))
+	sb.WriteString("\n);")
+	return sb.String()
+}
+
+func (d SQLiteDialect) FormatDropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", d.QuoteIdent(table))
+}
+
+func (d SQLiteDialect) FormatTruncateTable(table string) string {
+	return fmt.Sprintf("DELETE FROM %s;", d.QuoteIdent(table))
+}
+
+func (d SQLiteDialect) FormatBatchInsert(table string, columns []string, rows []string) string {
+	return formatInsertInto(d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(rows, ",\n"))
+}
+
+func (d SQLiteDialect) PlaceholderInsert(table string, columns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = "?"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(placeholders, ", "))
+}
+
+func (SQLiteDialect) DriverName() string { return "sqlite3" }
+
+func (SQLiteDialect) AutoIncrementType(genericType string) (colType, clause string, inlinePrimaryKey bool) {
+	// SQLite only honors AUTOINCREMENT on a column declared exactly
+	// "INTEGER PRIMARY KEY AUTOINCREMENT"; it can't be split across a
+	// separate table-level PRIMARY KEY constraint.
+	return "INTEGER", "PRIMARY KEY AUTOINCREMENT", true
+}
+
+func (d SQLiteDialect) LoadDataStatement(table string, columns []string, dataFile string, format CSVFormat) string {
+	return fmt.Sprintf(".mode csv\n.import '%s' %s\n", dataFile, table)
+}
+
+func (SQLiteDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// sqliteReservedWords is a non-exhaustive set of SQLite reserved keywords
+// likely to show up as CSV column headers.
+var sqliteReservedWords = newWordSet(
+	"abort", "action", "add", "after", "all", "alter", "analyze", "and",
+	"as", "asc", "between", "by", "case", "check", "collate", "column",
+	"commit", "constraint", "create", "cross", "default", "delete",
+	"desc", "distinct", "drop", "else", "escape", "except", "exists",
+	"foreign", "from", "full", "group", "having", "in", "index", "inner",
+	"insert", "intersect", "into", "is", "join", "key", "left", "like",
+	"limit", "not", "null", "on", "or", "order", "outer", "primary",
+	"references", "right", "select", "set", "table", "then", "to",
+	"transaction", "union", "unique", "update", "using", "values",
+	"when", "where",
+)
+
+func (SQLiteDialect) ReservedWords() map[string]bool { return sqliteReservedWords }
+
+// SQLite does not enforce an identifier length limit, so there's nothing
+// for sanitizeHeaders to truncate against.
+func (SQLiteDialect) MaxIdentifierLength() int { return 0 }
+
+////////////////////////////////////////////////////////////////////////////
+// Microsoft SQL Server
+
+// MSSQLDialect targets Microsoft SQL Server.
+type MSSQLDialect struct{}
+
+func (MSSQLDialect) Name() string { return "mssql" }
+
+func (MSSQLDialect) QuoteIdent(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+func (MSSQLDialect) MapType(genericType string) string {
+	base, unsigned := splitUnsigned(genericType)
+	switch {
+	case base == "TINYINT":
+		return "TINYINT"
+	case base == "SMALLINT":
+		if unsigned {
+			return "INT"
+		}
+		return "SMALLINT"
+	case base == "INT":
+		if unsigned {
+			return "BIGINT"
+		}
+		return "INT"
+	case base == "BIGINT":
+		if unsigned {
+			return "DECIMAL(20,0)"
+		}
+		return "BIGINT"
+	case genericType == "TEXT":
+		return "NVARCHAR(MAX)"
+	case strings.HasPrefix(genericType, "VARCHAR"):
+		return "N" + genericType
+	case strings.HasPrefix(genericType, "DECIMAL"):
+		return genericType
+	case genericType == "DATETIME":
+		return "DATETIME2"
+	case genericType == "BOOLEAN":
+		return "BIT"
+	case strings.HasPrefix(genericType, "ENUM("):
+		return "N" + enumFallback(genericType)
+	default:
+		return genericType
+	}
+}
+
+func (MSSQLDialect) EscapeString(value string) string {
+	// MSSQL only doubles single quotes; it has no backslash-escaping.
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (d MSSQLDialect) FormatCreateTable(table string, columns []string, ifNotExists bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("-- DROP TABLE %s;\n", table))
+	if ifNotExists {
+		sb.WriteString(fmt.Sprintf("IF OBJECT_ID(N'%s', N'U') IS NULL\n", table))
+	}
+	sb.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", d.QuoteIdent(table)))
+	sb.WriteString(strings.Join(columns, ",\n"))
+	sb.WriteString("\n);")
+	return sb.String()
+}
+
+func (d MSSQLDialect) FormatDropTable(table string) string {
+	return fmt.Sprintf("IF OBJECT_ID(N'%s', N'U') IS NOT NULL DROP TABLE %s;", table, d.QuoteIdent(table))
+}
+
+func (d MSSQLDialect) FormatTruncateTable(table string) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s;", d.QuoteIdent(table))
+}
+
+func (d MSSQLDialect) FormatBatchInsert(table string, columns []string, rows []string) string {
+	return formatInsertInto(d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(rows, ",\n"))
+}
+
+func (d MSSQLDialect) PlaceholderInsert(table string, columns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf("@p%d", i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(placeholders, ", "))
+}
+
+func (MSSQLDialect) DriverName() string { return "sqlserver" }
+
+func (d MSSQLDialect) LoadDataStatement(table string, columns []string, dataFile string, format CSVFormat) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("BULK INSERT %s\nFROM '%s'\nWITH (FIELDTERMINATOR = '%s', ROWTERMINATOR = '\\n', FIRSTROW = 1);\n",
+		d.QuoteIdent(table), dataFile, format.Separator))
+	sb.WriteString(fmt.Sprintf(
+		"\n-- Alternative, typically faster for large files: the bcp command-line\n"+
+			"-- utility with the %s format file written alongside this script:\n"+
+			"-- bcp %s in %s -f %s -S <server> -d <database> -T\n",
+		bcpFormatFileName(table), table, dataFile, bcpFormatFileName(table)))
+	return sb.String()
+}
+
+// bcpFormatFileName is the non-XML bcp format file generateBulkLoad writes
+// next to the CSV sidecar for a table, for use with the bcp command shown
+// in LoadDataStatement's output.
+func bcpFormatFileName(table string) string {
+	return table + ".fmt"
+}
+
+// BCPFormatFile renders a SQL Server non-XML bcp format file (version
+// 11.0) describing columns as comma-terminated SQLCHAR fields, with the
+// last column's terminator set to a newline. The comma terminator is
+// fixed, so it matches the CSV sidecar generateBulkLoad writes only when
+// the sidecar's separator is ",".
+func (MSSQLDialect) BCPFormatFile(columns []string) string {
+	var sb strings.Builder
+	sb.WriteString("11.0\n")
+	sb.WriteString(fmt.Sprintf("%d\n", len(columns)))
+	for i, col := range columns {
+		terminator := `","`
+		if i == len(columns)-1 {
+			terminator = `"\n"`
+		}
+		sb.WriteString(fmt.Sprintf("%-8dSQLCHAR             0       0       %-8s%-8d%-30s SQL_Latin1_General_CP1_CI_AS\n",
+			i+1, terminator, i+1, col))
+	}
+	return sb.String()
+}
+
+func (MSSQLDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (d MSSQLDialect) AutoIncrementType(genericType string) (colType, clause string, inlinePrimaryKey bool) {
+	return d.MapType(genericType), "IDENTITY(1,1)", false
+}
+
+// mssqlReservedWords is a non-exhaustive set of Transact-SQL reserved
+// keywords likely to show up as CSV column headers.
+var mssqlReservedWords = newWordSet(
+	"add", "all", "alter", "and", "any", "as", "asc", "between", "by",
+	"case", "check", "column", "constraint", "create", "current",
+	"current_date", "current_time", "current_timestamp", "database",
+	"default", "delete", "desc", "distinct", "drop", "else", "exists",
+	"for", "foreign", "from", "full", "group", "having", "in", "index",
+	"inner", "insert", "into", "is", "join", "key", "left", "like",
+	"not", "null", "on", "or", "order", "outer", "primary", "references",
+	"right", "select", "set", "table", "then", "to", "union", "unique",
+	"update", "user", "using", "values", "when", "where",
+)
+
+func (MSSQLDialect) ReservedWords() map[string]bool { return mssqlReservedWords }
+
+// SQL Server identifier limit: https://learn.microsoft.com/en-us/sql/t-sql/data-types/sysname-transact-sql
+func (MSSQLDialect) MaxIdentifierLength() int { return 128 }
+
+////////////////////////////////////////////////////////////////////////////
+// Oracle
+
+// OracleDialect targets Oracle Database.
+type OracleDialect struct{}
+
+func (OracleDialect) Name() string { return "oracle" }
+
+func (OracleDialect) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (OracleDialect) MapType(genericType string) string {
+	base, _ := splitUnsigned(genericType)
+	switch {
+	case base == "TINYINT":
+		return "NUMBER(3)"
+	case base == "SMALLINT":
+		return "NUMBER(5)"
+	case base == "INT":
+		return "NUMBER(10)"
+	case base == "BIGINT":
+		return "NUMBER(19)"
+	case strings.HasPrefix(genericType, "DECIMAL"):
+		return "NUMBER" + strings.TrimPrefix(genericType, "DECIMAL")
+	case strings.HasPrefix(genericType, "VARCHAR"):
+		return "VARCHAR2" + strings.TrimPrefix(genericType, "VARCHAR")
+	case genericType == "TEXT", genericType == "JSON":
+		return "CLOB"
+	case genericType == "DATE":
+		return "DATE"
+	case genericType == "DATETIME":
+		return "TIMESTAMP"
+	case genericType == "BOOLEAN":
+		return "NUMBER(1)"
+	case strings.HasPrefix(genericType, "ENUM("):
+		return "VARCHAR2" + strings.TrimPrefix(enumFallback(genericType), "VARCHAR")
+	default:
+		return genericType
+	}
+}
+
+func (OracleDialect) EscapeString(value string) string {
+	// Oracle, like ANSI SQL, only doubles single quotes; it has no
+	// backslash-escaping.
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (d OracleDialect) FormatCreateTable(table string, columns []string, ifNotExists bool) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("-- DROP TABLE %s;\n", table))
+	if ifNotExists {
+		// Oracle has no CREATE TABLE IF NOT EXISTS; FormatDropTable's
+		// PL/SQL guard is the idiomatic way to make table creation
+		// re-runnable instead.
+		sb.WriteString(d.FormatDropTable(table))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", d.QuoteIdent(table)))
+	sb.WriteString(strings.Join(columns, ",\n"))
+	sb.WriteString("\n);")
+	return sb.String()
+}
+
+func (d OracleDialect) FormatDropTable(table string) string {
+	return fmt.Sprintf(
+		"BEGIN\n"+
+			"  EXECUTE IMMEDIATE 'DROP TABLE %s';\n"+
+			"EXCEPTION\n"+
+			"  WHEN OTHERS THEN\n"+
+			"    IF SQLCODE != -942 THEN RAISE; END IF;\n"+
+			"END;\n/",
+		d.QuoteIdent(table))
+}
+
+func (d OracleDialect) FormatTruncateTable(table string) string {
+	return fmt.Sprintf("TRUNCATE TABLE %s;", d.QuoteIdent(table))
+}
+
+// FormatBatchInsert uses Oracle's INSERT ALL multi-table form, since
+// Oracle (unlike MySQL/Postgres/SQLite) has no multi-row VALUES list
+// syntax for plain INSERT.
+func (d OracleDialect) FormatBatchInsert(table string, columns []string, rows []string) string {
+	quotedTable := d.QuoteIdent(table)
+	columnList := quoteIdentList(d, columns)
+
+	var sb strings.Builder
+	sb.WriteString("INSERT ALL\n")
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("  INTO %s (%s) VALUES %s\n", quotedTable, columnList, row))
+	}
+	sb.WriteString("SELECT 1 FROM DUAL;\n")
+	return sb.String()
+}
+
+func (d OracleDialect) PlaceholderInsert(table string, columns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range placeholders {
+		placeholders[i] = fmt.Sprintf(":%d", i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", d.QuoteIdent(table), quoteIdentList(d, columns), strings.Join(placeholders, ", "))
+}
+
+func (OracleDialect) DriverName() string { return "oracle" }
+
+// LoadDataStatement renders a SQL*Loader control file that loads dataFile
+// into table.
+func (d OracleDialect) LoadDataStatement(table string, columns []string, dataFile string, format CSVFormat) string {
+	return fmt.Sprintf("LOAD DATA\nINFILE '%s'\nINTO TABLE %s\nFIELDS TERMINATED BY '%s' OPTIONALLY ENCLOSED BY '%s'\nTRAILING NULLCOLS\n(%s)\n",
+		dataFile, d.QuoteIdent(table), format.Separator, format.Quote, strings.Join(columns, ", "))
+}
+
+func (OracleDialect) BooleanLiteral(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+func (d OracleDialect) AutoIncrementType(genericType string) (colType, clause string, inlinePrimaryKey bool) {
+	return d.MapType(genericType), "GENERATED BY DEFAULT AS IDENTITY", false
+}
+
+// oracleReservedWords is a non-exhaustive set of Oracle reserved keywords
+// likely to show up as CSV column headers.
+var oracleReservedWords = newWordSet(
+	"access", "add", "all", "alter", "and", "any", "as", "asc", "between",
+	"by", "char", "check", "column", "comment", "compress", "connect",
+	"create", "current", "date", "decimal", "default", "delete", "desc",
+	"distinct", "drop", "else", "exclusive", "exists", "file", "float",
+	"for", "from", "grant", "group", "having", "identified", "in",
+	"increment", "index", "insert", "integer", "intersect", "into", "is",
+	"key", "level", "like", "lock", "long", "maxextents", "minus", "mode",
+	"modify", "noaudit", "nocompress", "not", "notfound", "nowait",
+	"null", "number", "of", "offline", "on", "online", "option", "or",
+	"order", "pctfree", "primary", "prior", "privileges", "public",
+	"raw", "rename", "resource", "revoke", "row", "rowid", "rownum",
+	"rows", "select", "session", "set", "share", "size", "smallint",
+	"start", "synonym", "sysdate", "table", "then", "to", "trigger",
+	"uid", "union", "unique", "update", "user", "using", "validate",
+	"values", "varchar", "varchar2", "view", "whenever", "where", "with",
+)
+
+func (OracleDialect) ReservedWords() map[string]bool { return oracleReservedWords }
+
+// Oracle Database 12.2+ identifier limit (30 bytes on earlier releases):
+// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Database-Object-Names-and-Qualifiers.html
+func (OracleDialect) MaxIdentifierLength() int { return 128 }
+
+// quoteIdentList quotes and joins a list of column names for use in an
+// INSERT column list.
+func quoteIdentList(d Dialect, columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = d.QuoteIdent(c)
+	}
+	return strings.Join(quoted, ", ")
+}
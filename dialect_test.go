@@ -0,0 +1,67 @@
+package csv2sql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMapTypeEnumFallback(t *testing.T) {
+	enum := "ENUM('blue','green','red')"
+	cases := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{PostgresDialect{}, "VARCHAR(7)"},
+		{SQLiteDialect{}, "TEXT"},
+		{MSSQLDialect{}, "NVARCHAR(7)"},
+		{OracleDialect{}, "VARCHAR2(7)"},
+	}
+	for _, tc := range cases {
+		if got := tc.dialect.MapType(enum); got != tc.want {
+			t.Errorf("%s.MapType(%q) = %q, want %q", tc.dialect.Name(), enum, got, tc.want)
+		}
+	}
+	// MySQL has native inline ENUM syntax, so it passes the token through.
+	if got := (MySQLDialect{}).MapType(enum); got != enum {
+		t.Errorf("MySQLDialect.MapType(%q) = %q, want unchanged", enum, got)
+	}
+}
+
+// TestBCPFormatFileColumnNameUnquoted is a regression test: the
+// server-column-name field of a non-XML bcp format file is a plain,
+// whitespace-delimited column name. %-30q (Go-quoting it) breaks bcp's
+// fixed-field parsing; it must be rendered with %-30s instead.
+func TestBCPFormatFileColumnNameUnquoted(t *testing.T) {
+	out := (MSSQLDialect{}).BCPFormatFile([]string{"user_id", "name"})
+	if strings.Contains(out, `"user_id"`) {
+		t.Errorf("BCPFormatFile quoted the column name, want a plain unquoted token:\n%s", out)
+	}
+	if !strings.Contains(out, "user_id") {
+		t.Errorf("BCPFormatFile output is missing column name user_id:\n%s", out)
+	}
+}
+
+func TestReservedWordsAndIdentifierLength(t *testing.T) {
+	cases := []struct {
+		dialect    Dialect
+		maxLen     int
+		reserved   string
+		unreserved string
+	}{
+		{MySQLDialect{}, 64, "order", "widget_count"},
+		{PostgresDialect{}, 63, "select", "widget_count"},
+		{MSSQLDialect{}, 128, "key", "widget_count"},
+		{OracleDialect{}, 128, "group", "widget_count"},
+	}
+	for _, tc := range cases {
+		if got := tc.dialect.MaxIdentifierLength(); got != tc.maxLen {
+			t.Errorf("%s.MaxIdentifierLength() = %d, want %d", tc.dialect.Name(), got, tc.maxLen)
+		}
+		if !tc.dialect.ReservedWords()[tc.reserved] {
+			t.Errorf("%s.ReservedWords()[%q] = false, want true", tc.dialect.Name(), tc.reserved)
+		}
+		if tc.dialect.ReservedWords()[tc.unreserved] {
+			t.Errorf("%s.ReservedWords()[%q] = true, want false", tc.dialect.Name(), tc.unreserved)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package csv2sql
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Exec opens c.ExecDSN via database/sql using the driver registered for
+// c.dialect, runs the DDL phase (optional DROP/TRUNCATE, then CREATE
+// TABLE), and streams the CSV rows from in through a prepared INSERT,
+// committing every TxSize rows. It reuses the same sampling-based
+// determineColumnTypes used by the text-generating code paths, so --exec
+// and plain SQL generation infer identical column types from the same
+// input.
+func (c *CSVToMySQLConverter) Exec(in io.Reader) error {
+	db, err := c.openDB()
+	if err != nil {
+		return fmt.Errorf("error opening database: %w", err)
+	}
+	defer db.Close()
+
+	reader, err := c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+
+	headers, err := c.readHeadersFromReader(reader)
+	if err != nil {
+		return fmt.Errorf("error reading headers: %w", err)
+	}
+
+	seeker, seekable := in.(io.Seeker)
+	if !seekable || c.Stdin {
+		return c.execSinglePass(db, reader, headers)
+	}
+	return c.execTwoPass(db, in, seeker, reader, headers)
+}
+
+// execTwoPass samples up to MaxSampleSize rows to determine column types,
+// runs the DDL phase, then seeks back to stream the DML in a second pass.
+func (c *CSVToMySQLConverter) execTwoPass(db *sql.DB, in io.Reader, seeker io.Seeker, reader *csv.Reader, headers []string) error {
+	columnTypes, nullable, err := c.determineColumnTypes(reader, headers)
+	if err != nil {
+		return fmt.Errorf("error determining column types: %w", err)
+	}
+	if err := c.execDDL(db, headers, columnTypes, nullable); err != nil {
+		return err
+	}
+
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error rewinding input: %w", err)
+	}
+	reader, err = c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+	if !c.NoHeader {
+		if _, err := reader.Read(); err != nil {
+			return fmt.Errorf("error skipping header: %w", err)
+		}
+	}
+
+	return c.execRows(db, reader, headers, columnTypes)
+}
+
+// execSinglePass buffers up to MaxSampleSize rows while inferring column
+// types, runs the DDL phase, then loads those buffered rows followed by
+// the remainder of reader. It never rewinds the input, so it works for
+// stdin and other non-seekable sources.
+func (c *CSVToMySQLConverter) execSinglePass(db *sql.DB, reader *csv.Reader, headers []string) error {
+	columnTypes := c.initColumnTypes(headers)
+	nullable := allNullable(headers)
+
+	var buffered [][]string
+	if !c.columnTypesAllForced(columnTypes) {
+		stats := c.initColumnStats(headers, columnTypes)
+		for len(buffered) < c.MaxSampleSize {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[execSinglePass] Warning: error reading record: %v\n", err)
+				continue
+			}
+			if len(record) != len(headers) {
+				log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+				continue
+			}
+			c.updateStats(stats, record)
+			buffered = append(buffered, record)
+		}
+		c.finalizeColumnTypes(headers, columnTypes, stats)
+		nullable = nullableFlags(headers, stats)
+	}
+
+	if err := c.execDDL(db, headers, columnTypes, nullable); err != nil {
+		return err
+	}
+
+	insert, err := c.newBatchInserter(db, headers, columnTypes)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, record := range buffered {
+		if err := insert.addRow(record); err != nil {
+			return err
+		}
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[execSinglePass] Warning: error reading record: %v\n", err)
+			continue
+		}
+		if len(record) != len(headers) {
+			log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+			continue
+		}
+		if err := insert.addRow(record); err != nil {
+			return err
+		}
+	}
+	return insert.flush()
+}
+
+// execRows streams reader's remaining rows into table through a
+// batchInserter.
+func (c *CSVToMySQLConverter) execRows(db *sql.DB, reader *csv.Reader, headers, columnTypes []string) error {
+	insert, err := c.newBatchInserter(db, headers, columnTypes)
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[execRows] Warning: error reading record: %v\n", err)
+			continue
+		}
+		if len(record) != len(headers) {
+			log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+			continue
+		}
+		if err := insert.addRow(record); err != nil {
+			return err
+		}
+	}
+	return insert.flush()
+}
+
+// execDDL runs the --drop-first / --truncate / CREATE TABLE statements
+// ahead of loading rows.
+func (c *CSVToMySQLConverter) execDDL(db *sql.DB, headers, columnTypes []string, nullable []bool) error {
+	if c.DropFirst {
+		if _, err := db.Exec(c.dialect.FormatDropTable(c.TableName)); err != nil {
+			return fmt.Errorf("error dropping table: %w", err)
+		}
+	}
+	if _, err := db.Exec(c.generateCreateTable(headers, columnTypes, nullable)); err != nil {
+		return fmt.Errorf("error creating table: %w", err)
+	}
+	if c.Truncate && !c.DropFirst {
+		if _, err := db.Exec(c.dialect.FormatTruncateTable(c.TableName)); err != nil {
+			return fmt.Errorf("error truncating table: %w", err)
+		}
+	}
+	return nil
+}
+
+// openDB opens c.ExecDSN with the driver c.dialect expects, applying any
+// --ssl-ca/--ssl-cert/--ssl-key options.
+func (c *CSVToMySQLConverter) openDB() (*sql.DB, error) {
+	dsn := c.ExecDSN
+	if c.SSLCA != "" || c.SSLCert != "" || c.SSLKey != "" {
+		var err error
+		dsn, err = c.applyTLSParams(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("error configuring TLS: %w", err)
+		}
+	}
+	db, err := sql.Open(c.dialect.DriverName(), dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error connecting to %s: %w", c.dialect.Name(), err)
+	}
+	return db, nil
+}
+
+// applyTLSParams wires up --ssl-ca/--ssl-cert/--ssl-key for the current
+// dialect, returning a DSN that requests the resulting TLS config.
+// Postgres already accepts these as DSN query parameters; MySQL requires
+// registering a named tls.Config with the driver first.
+func (c *CSVToMySQLConverter) applyTLSParams(dsn string) (string, error) {
+	switch c.dialect.Name() {
+	case "postgres":
+		params := make([]string, 0, 3)
+		if c.SSLCA != "" {
+			params = append(params, "sslrootcert="+c.SSLCA)
+		}
+		if c.SSLCert != "" {
+			params = append(params, "sslcert="+c.SSLCert)
+		}
+		if c.SSLKey != "" {
+			params = append(params, "sslkey="+c.SSLKey)
+		}
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return dsn + sep + strings.Join(params, "&"), nil
+
+	case "mysql":
+		tlsConfig := &tls.Config{}
+		if c.SSLCA != "" {
+			pem, err := os.ReadFile(c.SSLCA)
+			if err != nil {
+				return "", fmt.Errorf("error reading --ssl-ca: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return "", fmt.Errorf("error parsing --ssl-ca %s", c.SSLCA)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if c.SSLCert != "" && c.SSLKey != "" {
+			cert, err := tls.LoadX509KeyPair(c.SSLCert, c.SSLKey)
+			if err != nil {
+				return "", fmt.Errorf("error loading --ssl-cert/--ssl-key: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		if err := mysql.RegisterTLSConfig("csv2sql", tlsConfig); err != nil {
+			return "", err
+		}
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		return dsn + sep + "tls=csv2sql", nil
+
+	default:
+		return "", fmt.Errorf("--ssl-ca/--ssl-cert/--ssl-key are not supported for dialect %q", c.dialect.Name())
+	}
+}
+
+// batchInserter batches rows into transactions of TxSize, retrying a
+// transaction whose commit fails with a deadlock/serialization error.
+type batchInserter struct {
+	c          *CSVToMySQLConverter
+	db         *sql.DB
+	query      string
+	columnIdx  []int // indices into headers/columnTypes of non-skipped columns
+	columnType []string
+	tx         *sql.Tx
+	stmt       *sql.Stmt
+	pending    int
+	loaded     int
+	batchArgs  [][]interface{} // args of rows Exec'd against the current transaction, for replay on retry
+}
+
+func (c *CSVToMySQLConverter) newBatchInserter(db *sql.DB, headers, columnTypes []string) (*batchInserter, error) {
+	var idx []int
+	var types []string
+	for i, t := range columnTypes {
+		if t == "SKIP" {
+			continue
+		}
+		idx = append(idx, i)
+		types = append(types, t)
+	}
+	cols := c.formatInsertColumns(headers, columnTypes)
+	ins := &batchInserter{
+		c:          c,
+		db:         db,
+		query:      c.dialect.PlaceholderInsert(c.TableName, cols),
+		columnIdx:  idx,
+		columnType: types,
+	}
+	if err := ins.beginTx(); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+
+func (ins *batchInserter) beginTx() error {
+	tx, err := ins.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	stmt, err := tx.Prepare(ins.query)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error preparing insert: %w", err)
+	}
+	ins.tx, ins.stmt = tx, stmt
+	return nil
+}
+
+func (ins *batchInserter) addRow(record []string) error {
+	if ins.c.isNullRow(record) {
+		return nil
+	}
+	args := make([]interface{}, len(ins.columnIdx))
+	for i, col := range ins.columnIdx {
+		args[i] = ins.c.convertArg(record[col], ins.columnType[i])
+	}
+	if _, err := ins.stmt.Exec(args...); err != nil {
+		return fmt.Errorf("error inserting row: %w", err)
+	}
+	ins.batchArgs = append(ins.batchArgs, args)
+	ins.pending++
+	ins.loaded++
+	if ins.pending >= ins.c.TxSize {
+		if err := ins.commitWithRetry(); err != nil {
+			return err
+		}
+		if err := ins.beginTx(); err != nil {
+			return err
+		}
+		ins.pending = 0
+		ins.batchArgs = nil
+		fmt.Fprintf(os.Stderr, "[exec] loaded %d rows into %s\n", ins.loaded, ins.c.TableName)
+	}
+	return nil
+}
+
+func (ins *batchInserter) flush() error {
+	if ins.pending > 0 {
+		if err := ins.commitWithRetry(); err != nil {
+			return err
+		}
+		ins.batchArgs = nil
+	} else {
+		ins.tx.Rollback()
+	}
+	fmt.Fprintf(os.Stderr, "[exec] loaded %d rows into %s\n", ins.loaded, ins.c.TableName)
+	return nil
+}
+
+func (ins *batchInserter) commitWithRetry() error {
+	var err error
+	for attempt := 0; attempt <= ins.c.DeadlockRetries; attempt++ {
+		if err = ins.tx.Commit(); err == nil {
+			return nil
+		}
+		if !isRetryableTxError(err) || attempt == ins.c.DeadlockRetries {
+			return fmt.Errorf("error committing transaction: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "[exec] retrying transaction after %v (attempt %d/%d)\n", err, attempt+1, ins.c.DeadlockRetries)
+		time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+		if err := ins.beginTx(); err != nil {
+			return err
+		}
+		for _, args := range ins.batchArgs {
+			if _, err := ins.stmt.Exec(args...); err != nil {
+				return fmt.Errorf("error replaying row after retry: %w", err)
+			}
+		}
+	}
+	return err
+}
+
+func (ins *batchInserter) Close() error {
+	if ins.stmt != nil {
+		ins.stmt.Close()
+	}
+	return nil
+}
+
+// isRetryableTxError reports whether err looks like a transient
+// deadlock/serialization failure worth retrying, independent of dialect.
+func isRetryableTxError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "deadlock") || strings.Contains(msg, "serialization failure") || strings.Contains(msg, "lock wait timeout")
+}
+
+// convertArg converts a raw CSV field into the Go value that should be
+// bound to a prepared statement placeholder for columnType.
+func (c *CSVToMySQLConverter) convertArg(value, columnType string) interface{} {
+	value = strings.TrimSpace(value)
+	if c.isNullValue(value) {
+		return nil
+	}
+
+	switch {
+	case columnType == "BOOLEAN":
+		return isTruthy(value)
+	case columnType == "DATE":
+		return c.normalizeDateLiteral(value, false)
+	case columnType == "DATETIME":
+		return c.normalizeDateLiteral(value, true)
+	case isNumericType(columnType):
+		if strings.Contains(columnType, "INT") {
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return n
+			}
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
@@ -0,0 +1,154 @@
+package csv2sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestIsRetryableTxError(t *testing.T) {
+	cases := []struct {
+		msg  string
+		want bool
+	}{
+		{"Error 1213: Deadlock found when trying to get lock", true},
+		{"pq: could not serialize access due to concurrent update (serialization failure)", true},
+		{"Error 1205: Lock wait timeout exceeded", true},
+		{"duplicate entry '1' for key 'PRIMARY'", false},
+	}
+	for _, tc := range cases {
+		if got := isRetryableTxError(errors.New(tc.msg)); got != tc.want {
+			t.Errorf("isRetryableTxError(%q) = %v, want %v", tc.msg, got, tc.want)
+		}
+	}
+}
+
+func TestConvertArgDateNormalization(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql", InputTZ: "America/New_York", OutputTZ: "UTC"})
+
+	if got := c.convertArg("2026-01-02", "DATE"); got != "2026-01-02" {
+		t.Errorf("convertArg(DATE) = %v, want 2026-01-02", got)
+	}
+	got := c.convertArg("2026-01-02 10:00:00", "DATETIME")
+	if got != "2026-01-02 15:00:00" {
+		t.Errorf("convertArg(DATETIME) = %v, want 2026-01-02 15:00:00", got)
+	}
+	if got := c.convertArg("", "DATETIME"); got != nil {
+		t.Errorf("convertArg(empty, DATETIME) = %v, want nil", got)
+	}
+}
+
+// --- fake database/sql driver used to exercise commitWithRetry's retry path
+// without a live database connection ---
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 0, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+// fakeConn simulates a connection whose first Commit fails with a
+// retryable error; committed records only what was Exec'd against the
+// transaction that actually committed successfully.
+type fakeConn struct {
+	mu        sync.Mutex
+	committed []string
+	pending   []string
+	attempts  int
+	failTimes int
+}
+
+func (fc *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{conn: fc}, nil }
+func (fc *fakeConn) Close() error                              { return nil }
+
+func (fc *fakeConn) Begin() (driver.Tx, error) {
+	fc.mu.Lock()
+	fc.pending = nil
+	fc.mu.Unlock()
+	return &fakeTx{conn: fc}, nil
+}
+
+type fakeStmt struct{ conn *fakeConn }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.mu.Lock()
+	s.conn.pending = append(s.conn.pending, fmt.Sprint(args))
+	s.conn.mu.Unlock()
+	return fakeResult{}, nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("fakeStmt: Query not supported")
+}
+
+type fakeTx struct{ conn *fakeConn }
+
+func (tx *fakeTx) Commit() error {
+	tx.conn.mu.Lock()
+	defer tx.conn.mu.Unlock()
+	tx.conn.attempts++
+	if tx.conn.attempts <= tx.conn.failTimes {
+		return errors.New("Error 1213: Deadlock found when trying to get lock")
+	}
+	tx.conn.committed = append(tx.conn.committed, tx.conn.pending...)
+	tx.conn.pending = nil
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.conn.mu.Lock()
+	tx.conn.pending = nil
+	tx.conn.mu.Unlock()
+	return nil
+}
+
+type fakeDriver struct{ conn *fakeConn }
+
+func (d fakeDriver) Open(name string) (driver.Conn, error) { return d.conn, nil }
+
+// TestCommitWithRetryReplaysBatch is a regression test for a bug where a
+// retried commit opened a brand-new, empty transaction and committed that
+// instead of replaying the failed batch's rows: every row in the batch
+// was silently dropped while "loaded N rows" was still reported.
+func TestCommitWithRetryReplaysBatch(t *testing.T) {
+	conn := &fakeConn{failTimes: 1}
+	sql.Register("csv2sql_fake_retry", fakeDriver{conn: conn})
+
+	db, err := sql.Open("csv2sql_fake_retry", "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql", TableName: "t", TxSize: 5, DeadlockRetries: 1})
+	ins := &batchInserter{
+		c:          c,
+		db:         db,
+		query:      "INSERT INTO t (a) VALUES (?)",
+		columnIdx:  []int{0},
+		columnType: []string{"INT"},
+	}
+	if err := ins.beginTx(); err != nil {
+		t.Fatalf("beginTx: %v", err)
+	}
+	defer ins.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := ins.addRow([]string{fmt.Sprint(i)}); err != nil {
+			t.Fatalf("addRow(%d): %v", i, err)
+		}
+	}
+	if err := ins.flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	if len(conn.committed) != 3 {
+		t.Fatalf("expected 3 rows committed after the retry, got %d: %v", len(conn.committed), conn.committed)
+	}
+}
@@ -0,0 +1,252 @@
+package csv2sql
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// RunParallel drives a worker-pool version of the text-generating path: a
+// single reader goroutine parses records off in and fans them out over a
+// buffered channel to c.Workers goroutines, each of which formats its
+// share of rows into its own shard file under shardDir (inserts.0000.sql,
+// inserts.0001.sql, ...), so the shards can be loaded into the target
+// database in parallel afterwards with N concurrent mysql/psql clients.
+// The CREATE TABLE statement is written to ddlOut once, up front. Row
+// ordering across shards is not guaranteed unless c.Workers is 1, and in
+// requires a seekable input (--stdin is not supported here, since column
+// types must be sampled ahead of fanning rows out to workers).
+func (c *CSVToMySQLConverter) RunParallel(in io.Reader, ddlOut io.Writer, shardDir string) error {
+	seeker, seekable := in.(io.Seeker)
+	if !seekable || c.Stdin {
+		return fmt.Errorf("--workers requires a seekable input file (not --stdin)")
+	}
+
+	reader, err := c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+
+	headers, err := c.readHeadersFromReader(reader)
+	if err != nil {
+		return fmt.Errorf("error reading headers: %w", err)
+	}
+
+	columnTypes, nullable, err := c.determineColumnTypes(reader, headers)
+	if err != nil {
+		return fmt.Errorf("error determining column types: %w", err)
+	}
+	if _, err := io.WriteString(ddlOut, c.generateCreateTable(headers, columnTypes, nullable)); err != nil {
+		return err
+	}
+
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error rewinding input: %w", err)
+	}
+	reader, err = c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+	if !c.NoHeader {
+		if _, err := reader.Read(); err != nil {
+			return fmt.Errorf("error skipping header: %w", err)
+		}
+	}
+
+	workers := c.workerCount()
+	shards := make([]*os.File, workers)
+	for i := range shards {
+		path := filepath.Join(shardDir, fmt.Sprintf("inserts.%04d.sql", i))
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("error creating shard %s: %w", path, err)
+		}
+		defer f.Close()
+		shards[i] = f
+	}
+
+	jobs := make(chan []string, workers*4)
+	var rowCount int64
+	stopProgress := startProgressReporter(&rowCount)
+	defer stopProgress()
+
+	g := new(errgroup.Group)
+	for w := 0; w < workers; w++ {
+		out := shards[w]
+		g.Go(func() error {
+			var batch []string
+			flush := func() error {
+				if len(batch) == 0 {
+					return nil
+				}
+				if _, err := io.WriteString(out, c.formatBatchInsert(headers, columnTypes, batch)); err != nil {
+					return err
+				}
+				batch = batch[:0]
+				return nil
+			}
+			for record := range jobs {
+				if c.isNullRow(record) {
+					continue
+				}
+				batch = append(batch, c.formatRowValues(record, columnTypes))
+				atomic.AddInt64(&rowCount, 1)
+				if len(batch) >= c.BatchSize {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+			}
+			return flush()
+		})
+	}
+
+	g.Go(func() error {
+		defer close(jobs)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("error reading record: %w", err)
+			}
+			if len(record) != len(headers) {
+				log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+				continue
+			}
+			jobs <- record
+		}
+	})
+
+	return g.Wait()
+}
+
+// ExecParallel is the --exec analogue of RunParallel: a single reader
+// goroutine fans CSV records out to c.Workers goroutines, each loading
+// its share of rows into the database through its own prepared statement
+// and transaction batching (see batchInserter). Row ordering is not
+// guaranteed unless c.Workers is 1.
+func (c *CSVToMySQLConverter) ExecParallel(in io.Reader) error {
+	seeker, seekable := in.(io.Seeker)
+	if !seekable || c.Stdin {
+		return fmt.Errorf("--workers requires a seekable input file (not --stdin)")
+	}
+
+	db, err := c.openDB()
+	if err != nil {
+		return fmt.Errorf("error opening database: %w", err)
+	}
+	defer db.Close()
+
+	reader, err := c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+
+	headers, err := c.readHeadersFromReader(reader)
+	if err != nil {
+		return fmt.Errorf("error reading headers: %w", err)
+	}
+
+	columnTypes, nullable, err := c.determineColumnTypes(reader, headers)
+	if err != nil {
+		return fmt.Errorf("error determining column types: %w", err)
+	}
+	if err := c.execDDL(db, headers, columnTypes, nullable); err != nil {
+		return err
+	}
+
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error rewinding input: %w", err)
+	}
+	reader, err = c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+	if !c.NoHeader {
+		if _, err := reader.Read(); err != nil {
+			return fmt.Errorf("error skipping header: %w", err)
+		}
+	}
+
+	workers := c.workerCount()
+	jobs := make(chan []string, workers*4)
+	var rowCount int64
+	stopProgress := startProgressReporter(&rowCount)
+	defer stopProgress()
+
+	g := new(errgroup.Group)
+	for w := 0; w < workers; w++ {
+		g.Go(func() error {
+			insert, err := c.newBatchInserter(db, headers, columnTypes)
+			if err != nil {
+				return err
+			}
+			defer insert.Close()
+			for record := range jobs {
+				if err := insert.addRow(record); err != nil {
+					return err
+				}
+				atomic.AddInt64(&rowCount, 1)
+			}
+			return insert.flush()
+		})
+	}
+
+	g.Go(func() error {
+		defer close(jobs)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("error reading record: %w", err)
+			}
+			if len(record) != len(headers) {
+				log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+				continue
+			}
+			jobs <- record
+		}
+	})
+
+	return g.Wait()
+}
+
+// workerCount normalizes c.Workers to at least 1.
+func (c *CSVToMySQLConverter) workerCount() int {
+	if c.Workers < 1 {
+		return 1
+	}
+	return c.Workers
+}
+
+// startProgressReporter logs rows/sec once a second until the returned
+// stop func is called.
+func startProgressReporter(rowCount *int64) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		var last int64
+		for {
+			select {
+			case <-ticker.C:
+				cur := atomic.LoadInt64(rowCount)
+				fmt.Fprintf(os.Stderr, "[parallel] %d rows/sec (%d total)\n", cur-last, cur)
+				last = cur
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
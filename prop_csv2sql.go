@@ -1,8 +1,10 @@
-package main
+package csv2sql
 
 import (
+	"bufio"
 	"encoding/csv"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"log"
 	"os"
@@ -11,12 +13,16 @@ import (
 	"strings"
 )
 
-// CSVToMySQLConverter handles the conversion process
+// CSVToMySQLConverter handles the conversion process. Despite the name
+// (kept for backward compatibility), it targets whichever Dialect is
+// configured via OptsT.Dialect, not just MySQL.
 type CSVToMySQLConverter struct {
 	OptsT
-	NullString  string
-	ForceTypes  map[string]string // column name -> MySQL type
-	SkipColumns map[string]bool   // columns to skip
+	NullString    string
+	ForceTypes    map[string]string // column name -> SQL type
+	SkipColumns   map[string]bool   // columns to skip
+	ReservedWords map[string]bool   // additional reserved words, beyond the dialect's own, to disambiguate column names against
+	dialect       Dialect
 }
 
 var (
@@ -24,13 +30,61 @@ var (
 	leadingRegex  = regexp.MustCompile(`^[^a-zA-Z_]`)
 )
 
+// newCSVReader builds a csv.Reader configured from OptsT's CSV-read
+// options: Delimiter as the field separator, InComment as the comment
+// rune (if set), FieldsPerRecord and LazyQuotes passed straight through,
+// and TrimLeadingSpace always on. encoding/csv hardcodes '"' as the quote
+// character and has no backslash-escape concept, so InQuote/InEscape are
+// rejected outright rather than silently ignored when set to anything
+// other than their RFC 4180 defaults.
+func (c *CSVToMySQLConverter) newCSVReader(r io.Reader) (*csv.Reader, error) {
+	if c.InQuote != "" && c.InQuote != `"` {
+		return nil, fmt.Errorf(`--in-quote %q is not supported: encoding/csv only recognizes " as a quote character`, c.InQuote)
+	}
+	if c.InEscape != "" {
+		return nil, fmt.Errorf("--in-escape is not supported: encoding/csv has no backslash-escape handling, only RFC 4180 doubled-quote escaping")
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = rune(strings.TrimSpace(c.Delimiter)[0])
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = c.LazyQuotes
+	reader.FieldsPerRecord = c.FieldsPerRecord
+	if c.InComment != "" {
+		reader.Comment = rune(strings.TrimSpace(c.InComment)[0])
+	}
+	return reader, nil
+}
+
+// isNullValue reports whether value matches a configured NULL sentinel:
+// the empty string, the legacy single NullString, or any of NullStrings
+// (each compared case-insensitively).
+func (c *CSVToMySQLConverter) isNullValue(value string) bool {
+	if value == "" || strings.EqualFold(value, c.NullString) {
+		return true
+	}
+	for _, s := range c.NullStrings {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewCSVToMySQLConverter creates a new converter instance
 func NewCSVToMySQLConverter(optsT OptsT) *CSVToMySQLConverter {
+	dialect, err := DialectByName(optsT.Dialect)
+	if err != nil {
+		// Fall back to MySQL rather than fail construction; Convert()
+		// still returns an error path for bad user input elsewhere.
+		dialect = MySQLDialect{}
+	}
 	return &CSVToMySQLConverter{
 		OptsT:       optsT,
 		NullString:  "NULL",
 		ForceTypes:  make(map[string]string),
 		SkipColumns: make(map[string]bool),
+		dialect:     dialect,
 	}
 }
 
@@ -42,30 +96,40 @@ func (c *CSVToMySQLConverter) Convert() (string, string, error) {
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-	reader.Comma = rune(strings.TrimSpace(c.Delimiter)[0])
-	reader.TrimLeadingSpace = true
+	reader, err := c.newCSVReader(file)
+	if err != nil {
+		return "", "", err
+	}
 
 	headers, err := c.readHeaders(reader)
 	if err != nil {
 		return "", "", fmt.Errorf("error reading headers: %w", err)
 	}
 
-	columnTypes, err := c.determineColumnTypes(reader, headers)
+	columnTypes, nullable, err := c.determineColumnTypes(reader, headers)
 	if err != nil {
 		return "", "", fmt.Errorf("error determining column types: %w", err)
 	}
 
 	// Generate CREATE TABLE statement
-	createTable := c.generateCreateTable(headers, columnTypes)
+	createTable := c.generateCreateTable(headers, columnTypes, nullable)
 
-	// Generate INSERT statements
-	inserts, err := c.generateInsertStatements(file, headers, columnTypes)
-	if err != nil {
-		return "", "", fmt.Errorf("error generating insert statements: %w", err)
+	// Generate the DML: either batched INSERTs, or a native bulk-load
+	// script plus CSV sidecar when BulkLoad is set.
+	var dml string
+	if c.BulkLoad {
+		dml, err = c.generateBulkLoad(file, headers, columnTypes)
+		if err != nil {
+			return "", "", fmt.Errorf("error generating bulk-load script: %w", err)
+		}
+	} else {
+		dml, err = c.generateInsertStatements(file, headers, columnTypes)
+		if err != nil {
+			return "", "", fmt.Errorf("error generating insert statements: %w", err)
+		}
 	}
 
-	return createTable, inserts, nil
+	return createTable, dml, nil
 }
 
 func (c *CSVToMySQLConverter) readHeaders(reader *csv.Reader) ([]string, error) {
@@ -75,14 +139,7 @@ func (c *CSVToMySQLConverter) readHeaders(reader *csv.Reader) ([]string, error)
 			return nil, fmt.Errorf("error reading header: %w", err)
 		}
 
-		headers := make([]string, len(rawHeaders))
-		for i, h := range rawHeaders {
-			headers[i] = c.sanitizeColumnName(h)
-			if headers[i] == "" {
-				headers[i] = fmt.Sprintf("column_%d", i+1)
-			}
-		}
-		return headers, nil
+		return c.sanitizeHeaders(rawHeaders), nil
 	}
 
 	firstRow, err := reader.Read()
@@ -101,9 +158,10 @@ func (c *CSVToMySQLConverter) readHeaders(reader *csv.Reader) ([]string, error)
 	}
 	defer file.Close()
 
-	reader = csv.NewReader(file)
-	reader.Comma = rune(strings.TrimSpace(c.Delimiter)[0])
-	reader.TrimLeadingSpace = true
+	reader, err = c.newCSVReader(file)
+	if err != nil {
+		return nil, err
+	}
 
 	return headers, nil
 }
@@ -122,31 +180,73 @@ func (c *CSVToMySQLConverter) sanitizeColumnName(name string) string {
 	return strings.ToLower(name)
 }
 
-func (c *CSVToMySQLConverter) determineColumnTypes(reader *csv.Reader, headers []string) ([]string, error) {
-	columnTypes := make([]string, len(headers))
-	for i := range headers {
-		if forcedType, ok := c.ForceTypes[headers[i]]; ok {
-			columnTypes[i] = forcedType
-		} else if c.SkipColumns[headers[i]] {
-			columnTypes[i] = "SKIP"
-		} else {
-			columnTypes[i] = "TEXT"
+// sanitizeHeaders sanitizes a raw header row and disambiguates the
+// result: a name that collides with one of the dialect's reserved words
+// (or c.ReservedWords) gets an "_col" suffix, and a name that collides
+// with an earlier column gets a numeric "_2", "_3", ... suffix. Each
+// final name is then truncated to the dialect's identifier length limit,
+// if any, with a stable hash suffix so truncation can't itself introduce
+// a collision.
+func (c *CSVToMySQLConverter) sanitizeHeaders(rawHeaders []string) []string {
+	headers := make([]string, len(rawHeaders))
+	seen := make(map[string]bool, len(rawHeaders))
+	for i, h := range rawHeaders {
+		name := c.sanitizeColumnName(h)
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		if c.isReservedWord(name) {
+			name += "_col"
 		}
-	}
 
-	// If we have forced types for all columns, skip analysis
-	allForced := true
-	for _, t := range columnTypes {
-		if !strings.HasPrefix(t, "VARCHAR") && t != "SKIP" {
-			allForced = false
-			break
+		final := c.truncateIdentifier(name)
+		for suffix := 2; seen[final]; suffix++ {
+			final = c.truncateIdentifier(fmt.Sprintf("%s_%d", name, suffix))
 		}
+		seen[final] = true
+		headers[i] = final
 	}
-	if allForced {
-		return columnTypes, nil
+	return headers
+}
+
+// isReservedWord reports whether name (already lowercased by
+// sanitizeColumnName) is a reserved word for c.dialect or in the
+// user-supplied c.ReservedWords override.
+func (c *CSVToMySQLConverter) isReservedWord(name string) bool {
+	return c.dialect.ReservedWords()[name] || c.ReservedWords[name]
+}
+
+// truncateIdentifier shortens name to fit c.dialect.MaxIdentifierLength,
+// if any, replacing the trimmed tail with a stable hash of the full name
+// so two names that truncate to the same prefix don't collide.
+func (c *CSVToMySQLConverter) truncateIdentifier(name string) string {
+	max := c.dialect.MaxIdentifierLength()
+	if max <= 0 || len(name) <= max {
+		return name
+	}
+	hash := fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(name)))
+	keep := max - len(hash) - 1
+	if keep < 1 {
+		keep = 1
 	}
+	if keep > len(name) {
+		keep = len(name)
+	}
+	return name[:keep] + "_" + hash
+}
 
-	// Sample up to 1000 rows to determine types
+// determineColumnTypes samples reader to infer each column's canonical
+// type, also returning a nullable flag per column (true unless every
+// sampled value was non-null) so callers can render NOT NULL in the
+// generated DDL.
+func (c *CSVToMySQLConverter) determineColumnTypes(reader *csv.Reader, headers []string) ([]string, []bool, error) {
+	columnTypes := c.initColumnTypes(headers)
+	if c.columnTypesAllForced(columnTypes) {
+		return columnTypes, allNullable(headers), nil
+	}
+	stats := c.initColumnStats(headers, columnTypes)
+
+	// Sample up to MaxSampleSize rows to determine types
 	sampleCount := 0
 	for {
 		record, err := reader.Read()
@@ -165,25 +265,7 @@ func (c *CSVToMySQLConverter) determineColumnTypes(reader *csv.Reader, headers [
 			continue
 		}
 
-		for i, value := range record {
-			if columnTypes[i] == "SKIP" {
-				continue
-			}
-
-			// Skip if type is forced
-			if _, ok := c.ForceTypes[headers[i]]; ok {
-				continue
-			}
-
-			value = strings.TrimSpace(value)
-			if value == "" || strings.EqualFold(value, c.NullString) {
-				continue
-			}
-
-			if _, ok := c.ForceTypes[headers[i]]; !ok {
-				columnTypes[i] = c.refineType(columnTypes[i], value)
-			}
-		}
+		c.updateStats(stats, record)
 
 		sampleCount++
 		if sampleCount >= c.MaxSampleSize {
@@ -191,76 +273,149 @@ func (c *CSVToMySQLConverter) determineColumnTypes(reader *csv.Reader, headers [
 		}
 	}
 
-	return columnTypes, nil
+	c.finalizeColumnTypes(headers, columnTypes, stats)
+	return columnTypes, nullableFlags(headers, stats), nil
 }
 
-func (c *CSVToMySQLConverter) refineType(currentType, value string) string {
-	if isInteger(value) {
-		return "BIGINT"
-	}
-	if isDecimal(value) {
-		return "DECIMAL(20,6)"
-	}
-	if isDate(value) {
-		if len(value) > 10 {
-			return "DATETIME"
+// initColumnTypes seeds the per-column type slice from ForceTypes and
+// SkipColumns, defaulting everything else to TEXT pending sampling.
+func (c *CSVToMySQLConverter) initColumnTypes(headers []string) []string {
+	columnTypes := make([]string, len(headers))
+	for i := range headers {
+		if forcedType, ok := c.ForceTypes[headers[i]]; ok {
+			columnTypes[i] = forcedType
+		} else if c.SkipColumns[headers[i]] {
+			columnTypes[i] = "SKIP"
+		} else {
+			columnTypes[i] = "TEXT"
 		}
-		return "DATE"
 	}
+	return columnTypes
+}
 
-	length := len(value)
-	if length > c.TextThreshold {
-		return "TEXT"
-	}
-	if length > c.VarcharLength {
-		return fmt.Sprintf("VARCHAR(%d)", ((length/50)+1)*50)
+// columnTypesAllForced reports whether every column is skipped or forced
+// to a VARCHAR type, letting callers skip sampling entirely. A column
+// forced to any other type is not recognized here, so sampling still runs.
+func (c *CSVToMySQLConverter) columnTypesAllForced(columnTypes []string) bool {
+	for _, t := range columnTypes {
+		if !strings.HasPrefix(t, "VARCHAR") && t != "SKIP" {
+			return false
+		}
 	}
-	return fmt.Sprintf("VARCHAR(%d)", c.VarcharLength)
+	return true
 }
 
-// generateCreateTable generates the MySQL CREATE TABLE statement
-func (c *CSVToMySQLConverter) generateCreateTable(headers []string, columnTypes []string) string {
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("-- DROP TABLE %s;\n", c.TableName))
-	sb.WriteString(fmt.Sprintf("CREATE TABLE `%s` (\n", c.TableName))
+// finalizeColumnTypes resolves each column's stats (collected via
+// updateStats) into its final canonical type, leaving forced/skipped
+// columns untouched.
+func (c *CSVToMySQLConverter) finalizeColumnTypes(headers []string, columnTypes []string, stats []*ColumnStats) {
+	for i := range headers {
+		if stats[i] == nil {
+			continue
+		}
+		columnTypes[i] = c.finalizeColumnType(stats[i])
+	}
+	c.logTypeDecisions(headers, columnTypes, stats)
+}
 
+// generateCreateTable generates the dialect's CREATE TABLE statement.
+// nullable may be nil (no NOT NULL constraints emitted, e.g. when column
+// types were forced/skipped without sampling); otherwise nullable[i] ==
+// false appends NOT NULL to that column's definition.
+func (c *CSVToMySQLConverter) generateCreateTable(headers []string, columnTypes []string, nullable []bool) string {
 	columns := make([]string, 0, len(headers))
+	pkNames := append([]string{}, c.PrimaryKeys...)
+	inlinePK := false
+
 	for i, header := range headers {
 		if columnTypes[i] == "SKIP" {
 			continue
 		}
-		columns = append(columns, fmt.Sprintf("  `%s` %s", header, columnTypes[i]))
+		if c.AutoIncrement != "" && header == c.AutoIncrement {
+			colType, clause, isInlinePK := c.dialect.AutoIncrementType(columnTypes[i])
+			col := fmt.Sprintf("  %s %s", c.dialect.QuoteIdent(header), colType)
+			if clause != "" {
+				col += " " + clause
+			}
+			columns = append(columns, col)
+			if isInlinePK {
+				inlinePK = true
+			} else if !containsString(pkNames, header) {
+				pkNames = append(pkNames, header)
+			}
+			continue
+		}
+		col := fmt.Sprintf("  %s %s", c.dialect.QuoteIdent(header), c.dialect.MapType(columnTypes[i]))
+		if nullable != nil && !nullable[i] {
+			col += " NOT NULL"
+		}
+		columns = append(columns, col)
 	}
 
-	// Add primary key if specified
-	if len(c.PrimaryKeys) > 0 {
-		pkColumns := make([]string, 0, len(c.PrimaryKeys))
-		for _, pk := range c.PrimaryKeys {
-			pkColumns = append(pkColumns, fmt.Sprintf("`%s`", pk))
+	// Add primary key if specified (and not already declared inline by
+	// an --auto-increment column, e.g. SQLite's INTEGER PRIMARY KEY
+	// AUTOINCREMENT).
+	if !inlinePK && len(pkNames) > 0 {
+		pkColumns := make([]string, len(pkNames))
+		for i, pk := range pkNames {
+			pkColumns[i] = c.dialect.QuoteIdent(pk)
 		}
 		columns = append(columns, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pkColumns, ", ")))
 	}
 
-	sb.WriteString(strings.Join(columns, ",\n"))
-	sb.WriteString("\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;")
+	return c.dialect.FormatCreateTable(c.TableName, columns, c.IfNotExists)
+}
 
-	return sb.String()
+// containsString reports whether s is present in list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 // generateInsertStatements generates MySQL INSERT statements
 func (c *CSVToMySQLConverter) generateInsertStatements(file *os.File, headers []string, columnTypes []string) (string, error) {
 	// Reset file reader
 	file.Seek(0, 0)
-	reader := csv.NewReader(file)
-	reader.Comma = rune(strings.TrimSpace(c.Delimiter)[0])
+	reader, err := c.newCSVReader(file)
+	if err != nil {
+		return "", err
+	}
 
 	if !c.NoHeader {
 		reader.Read()
 	}
 
 	var sb strings.Builder
+	if err := c.streamInsertStatements(reader, headers, columnTypes, writerSink(&sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// dmlSink receives one batch's rendered INSERT statement text along with
+// the number of rows it contains, so callers that need to track row
+// counts across batches (see splitWriter) don't have to re-derive them
+// from the statement text.
+type dmlSink func(text string, rowCount int) error
+
+// writerSink adapts a plain io.Writer into a dmlSink, discarding the row
+// count.
+func writerSink(out io.Writer) dmlSink {
+	return func(text string, _ int) error {
+		_, err := io.WriteString(out, text)
+		return err
+	}
+}
+
+// streamInsertStatements reads records from reader until EOF, batching and
+// passing INSERT statements to sink as each batch fills, rather than
+// accumulating the whole DML text in memory.
+func (c *CSVToMySQLConverter) streamInsertStatements(reader *csv.Reader, headers []string, columnTypes []string, sink dmlSink) error {
 	var batchRows []string
-	rowCount := 0
 
 	for {
 		record, err := reader.Read()
@@ -279,130 +434,268 @@ func (c *CSVToMySQLConverter) generateInsertStatements(file *os.File, headers []
 			continue
 		}
 
-		// Skip empty rows
-		col := strings.TrimSpace(record[0])
-		isNull := col == "" ||
-			strings.EqualFold(col, c.NullString)
-		if len(record) >= 2 {
-			col = strings.TrimSpace(record[1])
-			isNull = isNull && (col == "" ||
-				strings.EqualFold(col, c.NullString))
-		}
-		if isNull {
+		if c.isNullRow(record) {
 			continue
 		}
 
-		// Prepare values
-		values := make([]string, 0, len(headers))
-		for i, value := range record {
-			if columnTypes[i] == "SKIP" {
-				continue
-			}
-
-			value = strings.TrimSpace(value)
-			if value == "" || strings.EqualFold(value, c.NullString) {
-				values = append(values, "NULL")
-				continue
-			}
-
-			// Escape special characters
-			escaped := strings.ReplaceAll(value, "'", "''")
-			escaped = strings.ReplaceAll(escaped, "\\", "\\\\")
-
-			// Add quotes unless it's a number or NULL
-			if columnTypes[i] == "INT" || columnTypes[i] == "DECIMAL(20,6)" {
-				// Try to parse as number to validate
-				if _, err := strconv.ParseFloat(value, 64); err == nil {
-					values = append(values, escaped)
-					continue
-				}
-			}
-			values = append(values, fmt.Sprintf("'%s'", escaped))
-		}
+		batchRows = append(batchRows, c.formatRowValues(record, columnTypes))
 
 		if !c.NoBatchInsert {
-			batchRows = append(batchRows, fmt.Sprintf("(%s)", strings.Join(values, ", ")))
 			if len(batchRows) >= c.BatchSize {
-				sb.WriteString(c.formatBatchInsert(headers, columnTypes, batchRows))
+				if err := sink(c.formatBatchInsert(headers, columnTypes, batchRows), len(batchRows)); err != nil {
+					return err
+				}
 				batchRows = batchRows[:0] // Clear batch
 			}
 		} else {
-			sb.WriteString(fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s);\n",
-				c.TableName,
-				c.formatInsertColumns(headers, columnTypes),
-				strings.Join(values, ", ")))
+			if err := sink(c.formatBatchInsert(headers, columnTypes, batchRows), len(batchRows)); err != nil {
+				return err
+			}
+			batchRows = batchRows[:0]
 		}
-
-		rowCount++
 	}
 
 	// Write any remaining batched rows
 	if len(batchRows) > 0 {
-		sb.WriteString(c.formatBatchInsert(headers, columnTypes, batchRows))
+		if err := sink(c.formatBatchInsert(headers, columnTypes, batchRows), len(batchRows)); err != nil {
+			return err
+		}
 	}
 
-	return sb.String(), nil
+	return nil
+}
+
+// isNullRow reports whether a record's leading columns (up to two) are
+// all empty or the configured NULL sentinel, marking it a blank row to
+// skip.
+func (c *CSVToMySQLConverter) isNullRow(record []string) bool {
+	isNull := c.isNullValue(strings.TrimSpace(record[0]))
+	if len(record) >= 2 {
+		isNull = isNull && c.isNullValue(strings.TrimSpace(record[1]))
+	}
+	return isNull
+}
+
+// formatRowValues renders one CSV record as a parenthesized SQL values
+// tuple, e.g. "(1, 'a', NULL)".
+func (c *CSVToMySQLConverter) formatRowValues(record []string, columnTypes []string) string {
+	values := make([]string, 0, len(record))
+	for i, value := range record {
+		if columnTypes[i] == "SKIP" {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if c.isNullValue(value) {
+			values = append(values, "NULL")
+			continue
+		}
+
+		if columnTypes[i] == "BOOLEAN" {
+			values = append(values, c.dialect.BooleanLiteral(isTruthy(value)))
+			continue
+		}
+
+		if columnTypes[i] == "DATE" || columnTypes[i] == "DATETIME" {
+			values = append(values, c.dialect.EscapeString(c.normalizeDateLiteral(value, columnTypes[i] == "DATETIME")))
+			continue
+		}
+
+		// Numbers pass through unquoted; everything else is a
+		// dialect-escaped string literal.
+		if isNumericType(columnTypes[i]) {
+			if _, err := strconv.ParseFloat(value, 64); err == nil {
+				values = append(values, value)
+				continue
+			}
+		}
+		values = append(values, c.dialect.EscapeString(value))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(values, ", "))
 }
 
-// formatInsertColumns formats the column list for INSERT statements
-func (c *CSVToMySQLConverter) formatInsertColumns(headers []string, columnTypes []string) string {
+// formatInsertColumns returns the non-skipped column names for INSERT
+// statements, in declaration order.
+func (c *CSVToMySQLConverter) formatInsertColumns(headers []string, columnTypes []string) []string {
 	var cols []string
 	for i, h := range headers {
 		if columnTypes[i] != "SKIP" {
-			cols = append(cols, fmt.Sprintf("`%s`", h))
+			cols = append(cols, h)
 		}
 	}
-	return strings.Join(cols, ", ")
+	return cols
 }
 
-// formatBatchInsert formats a batch INSERT statement
+// formatBatchInsert formats a batch INSERT statement via the dialect.
 func (c *CSVToMySQLConverter) formatBatchInsert(headers []string, columnTypes []string, rows []string) string {
-	return fmt.Sprintf("INSERT INTO `%s` (%s) VALUES\n%s;\n",
-		c.TableName,
-		c.formatInsertColumns(headers, columnTypes),
-		strings.Join(rows, ",\n"))
+	return c.dialect.FormatBatchInsert(c.TableName, c.formatInsertColumns(headers, columnTypes), rows)
 }
 
-// Helper functions from second implementation
-func isInteger(s string) bool {
-	_, err := strconv.ParseInt(s, 10, 64)
-	return err == nil
-}
+// generateBulkLoad writes a normalized CSV sidecar in the current working
+// directory (named "<table>.csv") using the configured --csv-* formatting, then
+// returns the dialect's native bulk-load script (LOAD DATA INFILE, COPY,
+// .import, ...) that consumes it.
+func (c *CSVToMySQLConverter) generateBulkLoad(file *os.File, headers []string, columnTypes []string) (string, error) {
+	// Reset file reader
+	file.Seek(0, 0)
+	reader, err := c.newCSVReader(file)
+	if err != nil {
+		return "", err
+	}
 
-func isDecimal(s string) bool {
-	_, err := strconv.ParseFloat(s, 64)
-	return err == nil
-}
+	if !c.NoHeader {
+		reader.Read()
+	}
 
-func isDate(s string) bool {
-	// Simple date patterns
-	patterns := []string{
-		`^\d{4}-\d{2}-\d{2}$`,                     // YYYY-MM-DD
-		`^\d{2}/\d{2}/\d{4}$`,                     // MM/DD/YYYY
-		`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`,   // YYYY-MM-DD HH:MM:SS
-		`^\d{2}/\d{2}/\d{4} \d{2}:\d{2}:\d{2}$`,   // MM/DD/YYYY HH:MM:SS
-		`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z?$`, // ISO8601
+	dataFile := c.TableName + ".csv"
+	out, err := os.Create(dataFile)
+	if err != nil {
+		return "", fmt.Errorf("error creating bulk-load data file: %w", err)
 	}
+	defer out.Close()
+
+	// encoding/csv only honors a field separator: its quoting is hardcoded
+	// to '"' and doubled-quote escaping, so it can't express the
+	// configured --csv-delimiter/--csv-escape the loader script below is
+	// told to expect. Write the sidecar by hand instead, so the script and
+	// the data file it loads always agree on quoting and escaping.
+	format := CSVFormat{
+		Separator: c.CSVSeparator,
+		Quote:     c.CSVDelimiter,
+		Escape:    c.CSVEscape,
+		Null:      c.CSVNull,
+	}
+	writer := bufio.NewWriter(out)
 
-	for _, pattern := range patterns {
-		matched, _ := regexp.MatchString(pattern, s)
-		if matched {
-			return true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr,
+				"[generateBulkLoad] Warning: error reading record: %v\n"+
+					"\tSkipping the record of: %#v\n", err, record)
+			continue
+		}
+
+		if len(record) != len(headers) {
+			log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+			continue
+		}
+
+		row := make([]string, 0, len(headers))
+		isNull := make([]bool, 0, len(headers))
+		for i, value := range record {
+			if columnTypes[i] == "SKIP" {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			if c.isNullValue(value) {
+				row = append(row, c.CSVNull)
+				isNull = append(isNull, true)
+				continue
+			}
+			row = append(row, value)
+			isNull = append(isNull, false)
+		}
+		if err := writeBulkLoadRow(writer, row, isNull, format); err != nil {
+			return "", fmt.Errorf("error writing bulk-load data file: %w", err)
 		}
 	}
-	return false
+	if err := writer.Flush(); err != nil {
+		return "", fmt.Errorf("error flushing bulk-load data file: %w", err)
+	}
+
+	insertColumns := c.formatInsertColumns(headers, columnTypes)
+
+	// MSSQL's bulk-load script offers the bcp command-line utility as a
+	// faster alternative to BULK INSERT; that requires a format file
+	// alongside the CSV sidecar describing the columns.
+	if mssql, ok := c.dialect.(MSSQLDialect); ok {
+		fmtFile, err := os.Create(bcpFormatFileName(c.TableName))
+		if err != nil {
+			return "", fmt.Errorf("error creating bcp format file: %w", err)
+		}
+		defer fmtFile.Close()
+		if _, err := io.WriteString(fmtFile, mssql.BCPFormatFile(insertColumns)); err != nil {
+			return "", fmt.Errorf("error writing bcp format file: %w", err)
+		}
+	}
+
+	return c.dialect.LoadDataStatement(c.TableName, insertColumns, dataFile, format), nil
 }
 
-// Escaping function from second implementation
-func escapeSQLValue(value string) string {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" || strings.EqualFold(trimmed, "NULL") {
-		return "NULL"
+// writeBulkLoadRow writes one row of the bulk-load CSV sidecar, quoting and
+// escaping fields per format so the data file always agrees with what the
+// generated loader script (FIELDS TERMINATED BY/OPTIONALLY ENCLOSED
+// BY/ESCAPED BY and dialect equivalents) is told to expect. A field whose
+// isNull flag is set is format.Null's sentinel and is written verbatim,
+// never quoted, so the loader recognizes it as NULL rather than the
+// literal string.
+func writeBulkLoadRow(w *bufio.Writer, row []string, isNull []bool, format CSVFormat) error {
+	for i, field := range row {
+		if i > 0 {
+			if _, err := w.WriteString(format.Separator); err != nil {
+				return err
+			}
+		}
+		if isNull[i] {
+			if _, err := w.WriteString(field); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := w.WriteString(quoteBulkLoadField(field, format)); err != nil {
+			return err
+		}
 	}
+	_, err := w.WriteString("\n")
+	return err
+}
+
+// quoteBulkLoadField escapes any occurrence of format.Escape in field
+// (recognized by the loader wherever it appears, not just inside quotes),
+// then encloses the field in format.Quote -- escaping any quote char it
+// contains with format.Escape -- if it contains the separator, the quote
+// char, or a newline.
+func quoteBulkLoadField(field string, format CSVFormat) string {
+	escaped := field
+	if format.Escape != "" {
+		escaped = strings.ReplaceAll(escaped, format.Escape, format.Escape+format.Escape)
+	}
+	if format.Quote == "" {
+		return escaped
+	}
+	needsQuote := strings.Contains(escaped, format.Separator) ||
+		strings.Contains(escaped, format.Quote) ||
+		strings.ContainsAny(escaped, "\n\r")
+	if !needsQuote {
+		return escaped
+	}
+	if format.Escape != "" {
+		escaped = strings.ReplaceAll(escaped, format.Quote, format.Escape+format.Quote)
+	} else {
+		escaped = strings.ReplaceAll(escaped, format.Quote, format.Quote+format.Quote)
+	}
+	return format.Quote + escaped + format.Quote
+}
 
-	escaped := strings.ReplaceAll(trimmed, "'", "''")
-	escaped = strings.ReplaceAll(escaped, "\\", "\\\\")
-	return "'" + escaped + "'"
+// legacyDatePatterns pairs each of isDate's historical regexes with the Go
+// time layout that parses a matching value, so a value recognized only by
+// the fallback regex (i.e. not matching any of --date-format/the richer
+// defaultDateLayouts) can still be parsed into a time.Time for
+// normalization rather than merely flagged as "looks like a date".
+var legacyDatePatterns = []struct {
+	regex  *regexp.Regexp
+	layout string
+}{
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "2006-01-02"},
+	{regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`), "01/02/2006"},
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`), "2006-01-02 15:04:05"},
+	{regexp.MustCompile(`^\d{2}/\d{2}/\d{4} \d{2}:\d{2}:\d{2}$`), "01/02/2006 15:04:05"},
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}$`), "2006-01-02T15:04:05"},
+	{regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`), "2006-01-02T15:04:05Z"},
 }
 
 /*
@@ -0,0 +1,49 @@
+package csv2sql
+
+import "testing"
+
+func TestSanitizeHeadersReservedWordAndCollision(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql"})
+	got := c.sanitizeHeaders([]string{"Order", "order", "order!"})
+	want := []string{"order_col", "order_col_2", "order_col_3"}
+	for i, w := range want {
+		if got[i] != w {
+			t.Errorf("sanitizeHeaders()[%d] = %q, want %q (full: %v)", i, got[i], w, got)
+		}
+	}
+}
+
+func TestSanitizeHeadersUserReservedWordsOverride(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql"})
+	c.ReservedWords = map[string]bool{"widget": true}
+	got := c.sanitizeHeaders([]string{"widget"})
+	if got[0] != "widget_col" {
+		t.Errorf("sanitizeHeaders() = %v, want [widget_col]", got)
+	}
+}
+
+// TestTruncateIdentifierStableAndCollisionFree is a regression check for
+// the truncation behavior sanitizeHeaders relies on: two names that share
+// a long common prefix must truncate to distinct identifiers rather than
+// silently colliding once cut to MaxIdentifierLength.
+func TestTruncateIdentifierStableAndCollisionFree(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql"}) // MaxIdentifierLength 64
+	long := make([]byte, 70)
+	for i := range long {
+		long[i] = 'a'
+	}
+	nameA := string(long) + "_one"
+	nameB := string(long) + "_two"
+
+	truncA := c.truncateIdentifier(nameA)
+	truncB := c.truncateIdentifier(nameB)
+	if len(truncA) > 64 || len(truncB) > 64 {
+		t.Fatalf("truncateIdentifier left a name over MySQL's 64-char limit: %q / %q", truncA, truncB)
+	}
+	if truncA == truncB {
+		t.Errorf("truncateIdentifier collapsed distinct names to the same identifier: %q", truncA)
+	}
+	if again := c.truncateIdentifier(nameA); again != truncA {
+		t.Errorf("truncateIdentifier is not stable: %q then %q", truncA, again)
+	}
+}
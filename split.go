@@ -0,0 +1,202 @@
+package csv2sql
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// WriteSplit is the file-splitting analogue of Run: it determines column
+// types the same way Run does (two-pass when in is seekable and Stdin is
+// not set, otherwise a bounded single-pass sample), writes the CREATE
+// TABLE statement to ddlOut, then streams INSERT statements into a
+// sequence of "<table>.NNNN.sql" files under dir, rotating to the next
+// file once the current one has accumulated rowsPerFile rows.
+// rowsPerFile <= 0 disables rotation, so everything lands in a single
+// "<table>.0001.sql" file. Setting c.NoTypeSample skips sampling
+// entirely, treating every non-forced, non-skipped column as TEXT, for a
+// true single pass over inputs too large to sample twice even once.
+func (c *CSVToMySQLConverter) WriteSplit(in io.Reader, ddlOut io.Writer, dir string, rowsPerFile int) error {
+	reader, err := c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+
+	headers, err := c.readHeadersFromReader(reader)
+	if err != nil {
+		return fmt.Errorf("error reading headers: %w", err)
+	}
+
+	if c.NoTypeSample {
+		columnTypes := c.initColumnTypes(headers)
+		if _, err := io.WriteString(ddlOut, c.generateCreateTable(headers, columnTypes, allNullable(headers))); err != nil {
+			return err
+		}
+		sw := newSplitWriter(dir, c.TableName, rowsPerFile)
+		defer sw.Close()
+		return c.streamInsertStatements(reader, headers, columnTypes, sw.write)
+	}
+
+	seeker, seekable := in.(io.Seeker)
+	if !seekable || c.Stdin {
+		return c.writeSplitSinglePass(reader, headers, ddlOut, dir, rowsPerFile)
+	}
+
+	columnTypes, nullable, err := c.determineColumnTypes(reader, headers)
+	if err != nil {
+		return fmt.Errorf("error determining column types: %w", err)
+	}
+	if _, err := io.WriteString(ddlOut, c.generateCreateTable(headers, columnTypes, nullable)); err != nil {
+		return err
+	}
+
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error rewinding input: %w", err)
+	}
+	reader, err = c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+	if !c.NoHeader {
+		if _, err := reader.Read(); err != nil {
+			return fmt.Errorf("error skipping header: %w", err)
+		}
+	}
+
+	sw := newSplitWriter(dir, c.TableName, rowsPerFile)
+	defer sw.Close()
+	return c.streamInsertStatements(reader, headers, columnTypes, sw.write)
+}
+
+// writeSplitSinglePass is WriteSplit's fallback for non-seekable input
+// (e.g. stdin): it buffers up to MaxSampleSize rows to infer types, same
+// as runSinglePass, then streams those buffered rows followed by the rest
+// of reader into the rotating split files.
+func (c *CSVToMySQLConverter) writeSplitSinglePass(reader *csv.Reader, headers []string, ddlOut io.Writer, dir string, rowsPerFile int) error {
+	columnTypes := c.initColumnTypes(headers)
+	nullable := allNullable(headers)
+
+	var buffered [][]string
+	if !c.columnTypesAllForced(columnTypes) {
+		stats := c.initColumnStats(headers, columnTypes)
+		for len(buffered) < c.MaxSampleSize {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[writeSplitSinglePass] Warning: error reading record: %v\n", err)
+				continue
+			}
+			if len(record) != len(headers) {
+				log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+				continue
+			}
+			c.updateStats(stats, record)
+			buffered = append(buffered, record)
+		}
+		c.finalizeColumnTypes(headers, columnTypes, stats)
+		nullable = nullableFlags(headers, stats)
+	}
+
+	if _, err := io.WriteString(ddlOut, c.generateCreateTable(headers, columnTypes, nullable)); err != nil {
+		return err
+	}
+
+	sw := newSplitWriter(dir, c.TableName, rowsPerFile)
+	defer sw.Close()
+
+	var batchRows []string
+	flush := func() error {
+		if len(batchRows) == 0 {
+			return nil
+		}
+		if err := sw.write(c.formatBatchInsert(headers, columnTypes, batchRows), len(batchRows)); err != nil {
+			return err
+		}
+		batchRows = batchRows[:0]
+		return nil
+	}
+	for _, record := range buffered {
+		if c.isNullRow(record) {
+			continue
+		}
+		batchRows = append(batchRows, c.formatRowValues(record, columnTypes))
+		if !c.NoBatchInsert {
+			if len(batchRows) >= c.BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		} else if err := flush(); err != nil {
+			return err
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return c.streamInsertStatements(reader, headers, columnTypes, sw.write)
+}
+
+// splitWriter rotates the underlying DML file every rowsPerFile rows,
+// naming each file "<table>.NNNN.sql" under dir (zero-padded to 4 digits
+// like RunParallel's inserts.NNNN.sql shard files, but 1-indexed, where
+// RunParallel's shards start at 0000).
+type splitWriter struct {
+	dir         string
+	table       string
+	rowsPerFile int
+
+	file       *os.File
+	fileIndex  int
+	rowsInFile int
+}
+
+func newSplitWriter(dir, table string, rowsPerFile int) *splitWriter {
+	return &splitWriter{dir: dir, table: table, rowsPerFile: rowsPerFile}
+}
+
+// write is a dmlSink: it renders the batch's rows into the current (or
+// next, if the current file is already at rowsPerFile) output file.
+func (w *splitWriter) write(text string, rowCount int) error {
+	if w.file == nil || (w.rowsPerFile > 0 && w.rowsInFile >= w.rowsPerFile) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w.file, text); err != nil {
+		return err
+	}
+	w.rowsInFile += rowCount
+	return nil
+}
+
+// rotate closes the current file, if any, and opens the next one.
+func (w *splitWriter) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+	}
+	w.fileIndex++
+	w.rowsInFile = 0
+	path := filepath.Join(w.dir, fmt.Sprintf("%s.%04d.sql", w.table, w.fileIndex))
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating split file %s: %w", path, err)
+	}
+	w.file = f
+	return nil
+}
+
+// Close closes the currently open output file, if any.
+func (w *splitWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
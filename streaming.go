@@ -0,0 +1,151 @@
+package csv2sql
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// Run converts CSV read from in into CREATE TABLE DDL and INSERT DML
+// written to ddlOut and dmlOut as rows are read, so gigabyte-scale inputs
+// never have to be buffered whole in memory.
+//
+// When in also implements io.Seeker (e.g. an *os.File) and Stdin is not
+// set, Run uses a two-pass strategy: one pass over up to MaxSampleSize
+// rows to determine column types, then a second pass from the start of the
+// file streaming the DML. Otherwise
+// (stdin, pipes) it falls back to a single-pass strategy that buffers up
+// to MaxSampleSize rows to infer types before streaming the rest.
+func (c *CSVToMySQLConverter) Run(in io.Reader, ddlOut, dmlOut io.Writer) error {
+	reader, err := c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+
+	headers, err := c.readHeadersFromReader(reader)
+	if err != nil {
+		return fmt.Errorf("error reading headers: %w", err)
+	}
+
+	if seeker, ok := in.(io.Seeker); ok && !c.Stdin {
+		return c.runTwoPass(in, seeker, reader, headers, ddlOut, dmlOut)
+	}
+	return c.runSinglePass(reader, headers, ddlOut, dmlOut)
+}
+
+// readHeadersFromReader reads and sanitizes the header row, or synthesizes
+// column_N names when NoHeader is set. Unlike readHeaders, it never
+// reopens the input file, so it works for non-seekable readers like
+// os.Stdin.
+func (c *CSVToMySQLConverter) readHeadersFromReader(reader *csv.Reader) ([]string, error) {
+	if !c.NoHeader {
+		rawHeaders, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("error reading header: %w", err)
+		}
+		return c.sanitizeHeaders(rawHeaders), nil
+	}
+
+	firstRow, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading first row: %w", err)
+	}
+	headers := make([]string, len(firstRow))
+	for i := range firstRow {
+		headers[i] = fmt.Sprintf("column_%d", i+1)
+	}
+	return headers, nil
+}
+
+// runTwoPass samples up to MaxSampleSize rows to determine column types,
+// then seeks back to the start and streams the DML in a second pass.
+func (c *CSVToMySQLConverter) runTwoPass(in io.Reader, seeker io.Seeker, reader *csv.Reader, headers []string, ddlOut, dmlOut io.Writer) error {
+	columnTypes, nullable, err := c.determineColumnTypes(reader, headers)
+	if err != nil {
+		return fmt.Errorf("error determining column types: %w", err)
+	}
+	if _, err := io.WriteString(ddlOut, c.generateCreateTable(headers, columnTypes, nullable)); err != nil {
+		return err
+	}
+
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error rewinding input: %w", err)
+	}
+	reader, err = c.newCSVReader(in)
+	if err != nil {
+		return err
+	}
+	if !c.NoHeader {
+		if _, err := reader.Read(); err != nil {
+			return fmt.Errorf("error skipping header: %w", err)
+		}
+	}
+
+	return c.streamInsertStatements(reader, headers, columnTypes, writerSink(dmlOut))
+}
+
+// runSinglePass buffers up to MaxSampleSize rows while inferring column
+// types, emits the DDL, then streams those buffered rows followed by the
+// remainder of reader as DML. It never rewinds the input, so it works for
+// stdin and other non-seekable sources.
+func (c *CSVToMySQLConverter) runSinglePass(reader *csv.Reader, headers []string, ddlOut, dmlOut io.Writer) error {
+	columnTypes := c.initColumnTypes(headers)
+	nullable := allNullable(headers)
+
+	var buffered [][]string
+	if !c.columnTypesAllForced(columnTypes) {
+		stats := c.initColumnStats(headers, columnTypes)
+		for len(buffered) < c.MaxSampleSize {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[runSinglePass] Warning: error reading record: %v\n", err)
+				continue
+			}
+			if len(record) != len(headers) {
+				log.Printf("Skipping row with %d columns (expected %d)", len(record), len(headers))
+				continue
+			}
+			c.updateStats(stats, record)
+			buffered = append(buffered, record)
+		}
+		c.finalizeColumnTypes(headers, columnTypes, stats)
+		nullable = nullableFlags(headers, stats)
+	}
+
+	if _, err := io.WriteString(ddlOut, c.generateCreateTable(headers, columnTypes, nullable)); err != nil {
+		return err
+	}
+
+	var batchRows []string
+	for _, record := range buffered {
+		if c.isNullRow(record) {
+			continue
+		}
+		batchRows = append(batchRows, c.formatRowValues(record, columnTypes))
+		if !c.NoBatchInsert {
+			if len(batchRows) >= c.BatchSize {
+				if _, err := io.WriteString(dmlOut, c.formatBatchInsert(headers, columnTypes, batchRows)); err != nil {
+					return err
+				}
+				batchRows = batchRows[:0]
+			}
+		} else {
+			if _, err := io.WriteString(dmlOut, c.formatBatchInsert(headers, columnTypes, batchRows)); err != nil {
+				return err
+			}
+			batchRows = batchRows[:0]
+		}
+	}
+	if len(batchRows) > 0 {
+		if _, err := io.WriteString(dmlOut, c.formatBatchInsert(headers, columnTypes, batchRows)); err != nil {
+			return err
+		}
+	}
+
+	return c.streamInsertStatements(reader, headers, columnTypes, writerSink(dmlOut))
+}
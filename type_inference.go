@@ -0,0 +1,504 @@
+package csv2sql
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// boolPairs are the truthy/falsy token pairs considered when --infer-bool
+// is set, tried in order. A column is only promoted to BOOLEAN if every
+// sampled value belongs to the same pair.
+var boolPairs = [][2]string{
+	{"true", "false"},
+	{"t", "f"},
+	{"yes", "no"},
+	{"1", "0"},
+}
+
+// defaultDateLayouts are tried, in order, before the legacy isDate regexes.
+var defaultDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.000000",
+	"01/02/2006",
+	"01/02/2006 15:04:05",
+	"02/01/2006",
+	"02/01/2006 15:04:05",
+}
+
+// ColumnStats tracks what has been observed for one column across the
+// sampled rows, so the final type can be chosen once sampling completes
+// rather than narrowed greedily row by row.
+type ColumnStats struct {
+	SawValue bool
+	Nullable bool
+	MaxLen   int
+
+	intSeen bool
+	AllInt  bool
+	MinInt  int64
+	MaxInt  int64
+
+	AllDecimal    bool
+	MaxIntDigits  int
+	MaxFracDigits int
+
+	AllBool  bool
+	boolPair int // index into boolPairs once determined, -1 until then
+
+	AllUUID bool
+	AllJSON bool
+
+	AllDate     bool
+	AnyDateTime bool
+
+	distinct    map[string]bool // nil once cardinality exceeds the column's enum threshold
+	enumOverrun bool
+}
+
+// newColumnStats returns a ColumnStats with every "All*" flag optimistically
+// true; each observed value can only narrow it to false.
+func newColumnStats() *ColumnStats {
+	return &ColumnStats{
+		AllInt:     true,
+		AllDecimal: true,
+		AllBool:    true,
+		AllUUID:    true,
+		AllJSON:    true,
+		AllDate:    true,
+		boolPair:   -1,
+		distinct:   make(map[string]bool),
+	}
+}
+
+// initColumnStats returns one ColumnStats per header, or nil for columns
+// that are forced or skipped (they need no sampling).
+func (c *CSVToMySQLConverter) initColumnStats(headers []string, columnTypes []string) []*ColumnStats {
+	stats := make([]*ColumnStats, len(headers))
+	for i := range headers {
+		if columnTypes[i] == "SKIP" {
+			continue
+		}
+		if _, ok := c.ForceTypes[headers[i]]; ok {
+			continue
+		}
+		stats[i] = newColumnStats()
+	}
+	return stats
+}
+
+// updateStats folds one CSV record into the per-column stats, skipping
+// forced/skipped columns and recording nulls rather than dropping them.
+func (c *CSVToMySQLConverter) updateStats(stats []*ColumnStats, record []string) {
+	for i, value := range record {
+		if stats[i] == nil {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		if c.isNullValue(value) {
+			stats[i].Nullable = true
+			continue
+		}
+
+		c.observeValue(stats[i], value)
+	}
+}
+
+// observeValue narrows a single column's stats with one non-null value.
+func (c *CSVToMySQLConverter) observeValue(s *ColumnStats, value string) {
+	s.SawValue = true
+	if len(value) > s.MaxLen {
+		s.MaxLen = len(value)
+	}
+
+	if !s.enumOverrun {
+		s.distinct[value] = true
+		if len(s.distinct) > c.enumThreshold() {
+			s.enumOverrun = true
+			s.distinct = nil
+		}
+	}
+
+	if s.AllBool {
+		s.AllBool = observeBool(s, value)
+	}
+	if s.AllUUID && !uuidRegex.MatchString(value) {
+		s.AllUUID = false
+	}
+	if s.AllJSON && !looksLikeJSON(value) {
+		s.AllJSON = false
+	}
+	if s.AllDate {
+		isDateVal, hasTime := c.parseAsDate(value)
+		if !isDateVal {
+			s.AllDate = false
+		} else if hasTime {
+			s.AnyDateTime = true
+		}
+	}
+
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if !s.intSeen {
+			s.MinInt, s.MaxInt, s.intSeen = n, n, true
+		} else {
+			if n < s.MinInt {
+				s.MinInt = n
+			}
+			if n > s.MaxInt {
+				s.MaxInt = n
+			}
+		}
+	} else {
+		s.AllInt = false
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		intDigits, fracDigits := digitCounts(value)
+		if intDigits > s.MaxIntDigits {
+			s.MaxIntDigits = intDigits
+		}
+		if fracDigits > s.MaxFracDigits {
+			s.MaxFracDigits = fracDigits
+		}
+	} else {
+		s.AllDecimal = false
+	}
+}
+
+// observeBool reports whether value is consistent with the column's bool
+// pair so far, locking in whichever pair the first value matched.
+func observeBool(s *ColumnStats, value string) bool {
+	lower := strings.ToLower(value)
+	if s.boolPair >= 0 {
+		pair := boolPairs[s.boolPair]
+		return lower == pair[0] || lower == pair[1]
+	}
+	for i, pair := range boolPairs {
+		if lower == pair[0] || lower == pair[1] {
+			s.boolPair = i
+			return true
+		}
+	}
+	return false
+}
+
+// isTruthy reports whether value is the truthy half of whichever boolPairs
+// entry it matches, checking the pairs in order; a value matching no pair
+// (e.g. in a forced BOOLEAN column) is reported as false.
+func isTruthy(value string) bool {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	for _, pair := range boolPairs {
+		if lower == pair[0] {
+			return true
+		}
+		if lower == pair[1] {
+			return false
+		}
+	}
+	return false
+}
+
+// looksLikeJSON reports whether value parses as a JSON object or array
+// (plain numbers/strings/booleans, while valid JSON, aren't treated as the
+// JSON column type).
+func looksLikeJSON(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+	return json.Valid([]byte(trimmed))
+}
+
+// digitCounts splits a numeric string into the digit counts before and
+// after the decimal point, ignoring a leading sign.
+func digitCounts(value string) (intDigits, fracDigits int) {
+	value = strings.TrimPrefix(value, "-")
+	value = strings.TrimPrefix(value, "+")
+	parts := strings.SplitN(value, ".", 2)
+	intDigits = len(parts[0])
+	if len(parts) == 2 {
+		fracDigits = len(parts[1])
+	}
+	return intDigits, fracDigits
+}
+
+// parseAsDate reports whether value matches one of the configured
+// --date-format layouts (tried first), a Unix epoch seconds/milliseconds
+// token (only with --infer-epoch), or the legacy isDate regexes, and whether the matched
+// representation carries a time-of-day component.
+func (c *CSVToMySQLConverter) parseAsDate(value string) (isDateVal, hasTime bool) {
+	_, isDateVal, hasTime = c.parseDateValue(value)
+	return isDateVal, hasTime
+}
+
+// parseDateValue is parseAsDate's value-returning counterpart: it tries
+// the same layouts (plus epoch seconds/milliseconds), interpreting any
+// layout with no zone offset of its own in --input-tz, and returns the
+// parsed time.Time so formatRowValues can normalize the value on INSERT
+// instead of passing the raw, possibly ambiguous, CSV token through.
+func (c *CSVToMySQLConverter) parseDateValue(value string) (t time.Time, ok, hasTime bool) {
+	layouts := c.DateFormats
+	if len(layouts) == 0 {
+		layouts = defaultDateLayouts
+	}
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, c.inputLocation()); err == nil {
+			return parsed, true, strings.ContainsAny(layout, ":")
+		}
+	}
+	if c.InferEpoch {
+		if parsed, epochOK := parseEpoch(value); epochOK {
+			return parsed, true, true
+		}
+	}
+	for _, p := range legacyDatePatterns {
+		if !p.regex.MatchString(value) {
+			continue
+		}
+		if parsed, err := time.ParseInLocation(p.layout, value, c.inputLocation()); err == nil {
+			return parsed, true, strings.ContainsAny(p.layout, ":")
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// normalizeDateLiteral renders value (already known to satisfy a DATE or
+// DATETIME column) in canonical "YYYY-MM-DD" / "YYYY-MM-DD HH:MM:SS" form,
+// converted into --output-tz. If value no longer parses (e.g. a forced
+// DATE/DATETIME column fed a value sampling never saw), it's passed
+// through unchanged rather than dropped.
+func (c *CSVToMySQLConverter) normalizeDateLiteral(value string, hasTime bool) string {
+	t, ok, _ := c.parseDateValue(value)
+	if !ok {
+		return value
+	}
+	t = t.In(c.outputLocation())
+	if !hasTime {
+		return t.Format("2006-01-02")
+	}
+	if t.Nanosecond() != 0 {
+		return t.Format("2006-01-02 15:04:05.000000")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// inputLocation resolves --input-tz (default UTC), used to interpret
+// naive date/time values whose layout carries no zone offset of its own.
+func (c *CSVToMySQLConverter) inputLocation() *time.Location {
+	return resolveLocation(c.InputTZ)
+}
+
+// outputLocation resolves --output-tz (default UTC), that normalized
+// DATE/DATETIME literals are converted into before being rendered.
+func (c *CSVToMySQLConverter) outputLocation() *time.Location {
+	return resolveLocation(c.OutputTZ)
+}
+
+// resolveLocation loads an IANA zone name, falling back to UTC for an
+// empty name or one the tzdata database doesn't recognize.
+func resolveLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// parseEpoch recognizes value as Unix epoch seconds (10 digits) or
+// milliseconds (13 digits), the two lengths that unambiguously distinguish
+// the two units for dates in the modern era. parseDateValue only tries it
+// when --infer-epoch is set, since a column of plain 10- or 13-digit
+// integers (e.g. phone numbers, order IDs) has the same shape and would
+// otherwise risk being misread as epoch timestamps.
+func parseEpoch(value string) (time.Time, bool) {
+	if !isAllDigits(value) {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	switch len(value) {
+	case 10:
+		return time.Unix(n, 0).UTC(), true
+	case 13:
+		return time.UnixMilli(n).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericType reports whether a canonical column type is numeric, and so
+// its values should be emitted unquoted in INSERT statements.
+func isNumericType(columnType string) bool {
+	switch {
+	case columnType == "TINYINT", columnType == "SMALLINT", columnType == "INT", columnType == "BIGINT":
+		return true
+	case strings.HasSuffix(columnType, " UNSIGNED"):
+		return true
+	case strings.HasPrefix(columnType, "DECIMAL"):
+		return true
+	default:
+		return false
+	}
+}
+
+// intWidth picks the narrowest MySQL-style integer type that spans
+// [min, max], appending UNSIGNED when the column never went negative.
+func intWidth(min, max int64) string {
+	width := "BIGINT"
+	switch {
+	case min >= 0 && max <= 255:
+		width = "TINYINT"
+	case min >= -128 && max <= 127:
+		width = "TINYINT"
+	case min >= 0 && max <= 65535:
+		width = "SMALLINT"
+	case min >= -32768 && max <= 32767:
+		width = "SMALLINT"
+	case min >= 0 && max <= 4294967295:
+		width = "INT"
+	case min >= -2147483648 && max <= 2147483647:
+		width = "INT"
+	}
+	if min >= 0 {
+		return width + " UNSIGNED"
+	}
+	return width
+}
+
+// enumThreshold is the max number of distinct sampled values a column may
+// have and still be eligible for --infer-enum, from --enum-threshold.
+func (c *CSVToMySQLConverter) enumThreshold() int {
+	if c.EnumThreshold > 0 {
+		return c.EnumThreshold
+	}
+	return 16
+}
+
+// enumType renders a canonical ENUM(...) token from a column's distinct
+// sampled values, sorted so repeated runs over the same input produce
+// byte-identical DDL.
+func enumType(distinct map[string]bool) string {
+	values := make([]string, 0, len(distinct))
+	for v := range distinct {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return fmt.Sprintf("ENUM(%s)", strings.Join(quoted, ","))
+}
+
+// nullableFlags reports, per header, whether a NULL sentinel was observed
+// for that column while sampling. Forced/skipped columns (nil stats) were
+// never sampled, so they're conservatively reported as nullable.
+func nullableFlags(headers []string, stats []*ColumnStats) []bool {
+	nullable := make([]bool, len(headers))
+	for i := range headers {
+		if stats[i] == nil {
+			nullable[i] = true
+			continue
+		}
+		nullable[i] = stats[i].Nullable
+	}
+	return nullable
+}
+
+// allNullable marks every column nullable, for code paths that skip
+// sampling entirely (e.g. --no-type-sample, or every column forced/skipped).
+func allNullable(headers []string) []bool {
+	nullable := make([]bool, len(headers))
+	for i := range nullable {
+		nullable[i] = true
+	}
+	return nullable
+}
+
+// logTypeDecisions prints, in --verbose mode, a compact decision table
+// recording the final type chosen for each sampled column and the stats
+// behind it, so users can see why a column did or didn't come out as the
+// BOOLEAN/JSON/UUID/ENUM they may have expected.
+func (c *CSVToMySQLConverter) logTypeDecisions(headers, columnTypes []string, stats []*ColumnStats) {
+	if c.Verbose == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "[type-inference] column decisions:")
+	for i, header := range headers {
+		if stats[i] == nil {
+			fmt.Fprintf(os.Stderr, "  %-20s %-20s (forced or skipped)\n", header, columnTypes[i])
+			continue
+		}
+		s := stats[i]
+		fmt.Fprintf(os.Stderr,
+			"  %-20s %-20s nullable=%v allInt=%v allDecimal=%v allBool=%v allUUID=%v allJSON=%v allDate=%v distinct=%d\n",
+			header, columnTypes[i], s.Nullable, s.AllInt, s.AllDecimal, s.AllBool, s.AllUUID, s.AllJSON, s.AllDate, len(s.distinct))
+	}
+}
+
+// finalizeColumnType picks a column's final canonical type from its
+// sampled stats, falling back to the existing VARCHAR/TEXT sizing rules
+// for plain strings.
+func (c *CSVToMySQLConverter) finalizeColumnType(s *ColumnStats) string {
+	if !s.SawValue {
+		return fmt.Sprintf("VARCHAR(%d)", c.VarcharLength)
+	}
+
+	switch {
+	case c.InferBool && s.AllBool:
+		return "BOOLEAN"
+	case c.InferUUID && s.AllUUID:
+		return "CHAR(36)"
+	case c.InferJSON && s.AllJSON:
+		return "JSON"
+	case s.AllDate:
+		if s.AnyDateTime {
+			return "DATETIME"
+		}
+		return "DATE"
+	case s.AllInt:
+		return intWidth(s.MinInt, s.MaxInt)
+	case s.AllDecimal:
+		precision := s.MaxIntDigits + s.MaxFracDigits
+		if precision < 1 {
+			precision = 1
+		}
+		return fmt.Sprintf("DECIMAL(%d,%d)", precision, s.MaxFracDigits)
+	case c.InferEnum && !s.enumOverrun && len(s.distinct) > 0:
+		return enumType(s.distinct)
+	}
+
+	if s.MaxLen > c.TextThreshold {
+		return "TEXT"
+	}
+	if s.MaxLen > c.VarcharLength {
+		return fmt.Sprintf("VARCHAR(%d)", ((s.MaxLen/50)+1)*50)
+	}
+	return fmt.Sprintf("VARCHAR(%d)", c.VarcharLength)
+}
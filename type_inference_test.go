@@ -0,0 +1,116 @@
+package csv2sql
+
+import "testing"
+
+// columnType samples values through a fresh ColumnStats and returns the
+// type finalizeColumnType picks for them, so inference tests can be
+// written as plain value lists instead of poking at ColumnStats fields.
+func columnType(t *testing.T, c *CSVToMySQLConverter, values ...string) string {
+	t.Helper()
+	s := newColumnStats()
+	for _, v := range values {
+		c.observeValue(s, v)
+	}
+	return c.finalizeColumnType(s)
+}
+
+func TestFinalizeColumnTypeIntWidening(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql", VarcharLength: 255, TextThreshold: 100})
+	cases := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"1", "2", "200"}, "TINYINT UNSIGNED"},
+		{[]string{"1", "-5", "120"}, "TINYINT"},
+		{[]string{"1", "70000"}, "INT UNSIGNED"},
+		{[]string{"1", "-70000"}, "INT"},
+	}
+	for _, tc := range cases {
+		if got := columnType(t, c, tc.values...); got != tc.want {
+			t.Errorf("columnType(%v) = %q, want %q", tc.values, got, tc.want)
+		}
+	}
+}
+
+func TestFinalizeColumnTypeBoolUUIDJSON(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{
+		Dialect: "mysql", VarcharLength: 255, TextThreshold: 100,
+		InferBool: true, InferUUID: true, InferJSON: true,
+	})
+
+	if got := columnType(t, c, "true", "false", "TRUE"); got != "BOOLEAN" {
+		t.Errorf("bool column = %q, want BOOLEAN", got)
+	}
+	if got := columnType(t, c, "550e8400-e29b-41d4-a716-446655440000"); got != "CHAR(36)" {
+		t.Errorf("uuid column = %q, want CHAR(36)", got)
+	}
+	if got := columnType(t, c, `{"a":1}`, `[1,2,3]`); got != "JSON" {
+		t.Errorf("json column = %q, want JSON", got)
+	}
+	// Mixed true/false tokens from different pairs must not infer BOOLEAN.
+	if got := columnType(t, c, "yes", "false"); got == "BOOLEAN" {
+		t.Errorf("mixed bool-pair column inferred BOOLEAN, want a string/text fallback, got %q", got)
+	}
+}
+
+func TestFinalizeColumnTypeEnum(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{
+		Dialect: "mysql", VarcharLength: 255, TextThreshold: 100,
+		InferEnum: true, EnumThreshold: 3,
+	})
+
+	if got := columnType(t, c, "red", "green", "red", "blue"); got != "ENUM('blue','green','red')" {
+		t.Errorf("low-cardinality column = %q, want ENUM('blue','green','red')", got)
+	}
+	// Distinct count above --enum-threshold must fall back to VARCHAR/TEXT, not ENUM.
+	if got := columnType(t, c, "a", "b", "c", "d"); got == "ENUM('a','b','c','d')" {
+		t.Errorf("over-threshold column inferred ENUM, want fallback, got %q", got)
+	}
+}
+
+func TestFinalizeColumnTypeDateVsDateTime(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql", VarcharLength: 255, TextThreshold: 100})
+
+	if got := columnType(t, c, "2026-01-02", "2026-03-04"); got != "DATE" {
+		t.Errorf("date-only column = %q, want DATE", got)
+	}
+	if got := columnType(t, c, "2026-01-02 10:11:12", "2026-03-04 00:00:00"); got != "DATETIME" {
+		t.Errorf("datetime column = %q, want DATETIME", got)
+	}
+}
+
+// TestFinalizeColumnTypeEpochRequiresOptIn is a regression test: by default
+// (no --infer-epoch) a column of plain 10-digit integers, like US phone
+// numbers, must stay an integer type rather than being reinterpreted as a
+// Unix epoch DATETIME.
+func TestFinalizeColumnTypeEpochRequiresOptIn(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql", VarcharLength: 255, TextThreshold: 100})
+	phones := []string{"4155551234", "2125550000"}
+
+	if got := columnType(t, c, phones...); got == "DATETIME" {
+		t.Errorf("phone-number column inferred DATETIME without --infer-epoch, got %q", got)
+	}
+
+	c.InferEpoch = true
+	if got := columnType(t, c, phones...); got != "DATETIME" {
+		t.Errorf("phone-number column with --infer-epoch = %q, want DATETIME", got)
+	}
+}
+
+func TestNormalizeDateLiteralTimezoneConversion(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{
+		Dialect: "mysql", InputTZ: "America/New_York", OutputTZ: "UTC",
+	})
+	got := c.normalizeDateLiteral("2026-01-02 10:00:00", true)
+	want := "2026-01-02 15:00:00" // EST is UTC-5 in January
+	if got != want {
+		t.Errorf("normalizeDateLiteral = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeDateLiteralUnparsablePassesThrough(t *testing.T) {
+	c := NewCSVToMySQLConverter(OptsT{Dialect: "mysql"})
+	if got := c.normalizeDateLiteral("not-a-date", true); got != "not-a-date" {
+		t.Errorf("normalizeDateLiteral(unparsable) = %q, want unchanged input", got)
+	}
+}